@@ -0,0 +1,226 @@
+// Package analytics computes flow results analytics from the runs of a flow.
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/rasoro/rapidpro-api-go/v2/flows"
+	"github.com/rasoro/rapidpro-api-go/v2/runs"
+)
+
+// Report holds the analytics of a flow for a time window
+type Report struct {
+	FlowUUID string
+	FlowName string
+	After    *time.Time
+	Before   *time.Time
+
+	Runs        int
+	Active      int
+	Completed   int
+	Interrupted int
+	Expired     int
+
+	CompletionRate   float64
+	InterruptionRate float64
+	ExpiryRate       float64
+
+	// MedianTimeToComplete is the median time between a run being created and exiting, over completed runs
+	MedianTimeToComplete time.Duration
+
+	Results []ResultDistribution
+	DropOff []NodeDropOff
+}
+
+// ResultDistribution is the distribution of the categories of a result key
+type ResultDistribution struct {
+	Key        string
+	Name       string
+	Total      int
+	Categories []CategoryCount
+}
+
+// CategoryCount is the number of runs with a result in a category, and the fraction of the runs with the result
+// it is, like the rates of Report
+type CategoryCount struct {
+	Name  string
+	Count int
+	Rate  float64
+}
+
+// NodeDropOff is the number of runs that visited a node and of those that were interrupted or expired there
+type NodeDropOff struct {
+	Node     string
+	Visits   int
+	DropOffs int
+	Rate     float64
+}
+
+// Compute builds a Report for the flow from its runs, see Accumulator
+func Compute(flow *flows.Flow, flowRuns []runs.Run) *Report {
+	accumulator := NewAccumulator(flow)
+	accumulator.Add(flowRuns)
+	return accumulator.Report()
+}
+
+// Accumulator builds the Report of a flow from its runs added a page at a time, so that the runs don't need to
+// be kept in memory. Runs of other flows are ignored. Result keys are reported in the order of the flow results
+// metadata, with categories that weren't declared there appended at the end.
+type Accumulator struct {
+	flow          *flows.Flow
+	report        *Report
+	distributions []ResultDistribution
+	byKey         map[string]*categoryCounter
+	visits        map[string]int
+	dropOffs      map[string]int
+	durations     []time.Duration
+}
+
+// NewAccumulator returns an Accumulator for the runs of the flow
+func NewAccumulator(flow *flows.Flow) *Accumulator {
+	a := &Accumulator{
+		flow:          flow,
+		report:        &Report{FlowUUID: flow.UUID, FlowName: flow.Name},
+		distributions: make([]ResultDistribution, 0, len(flow.Results)),
+		byKey:         make(map[string]*categoryCounter, len(flow.Results)),
+		visits:        make(map[string]int),
+		dropOffs:      make(map[string]int),
+	}
+	for _, result := range flow.Results {
+		a.distributions = append(a.distributions, ResultDistribution{Key: result.Key, Name: result.Name})
+		a.byKey[result.Key] = newCategoryCounter(result.Categories)
+	}
+	return a
+}
+
+// Add counts a page of runs
+func (a *Accumulator) Add(flowRuns []runs.Run) {
+	report := a.report
+	for i := range flowRuns {
+		run := &flowRuns[i]
+		if run.Flow.UUID != a.flow.UUID {
+			continue
+		}
+		report.Runs++
+
+		switch run.ExitType {
+		case runs.ExitTypeCompleted:
+			report.Completed++
+			if run.CreatedOn != nil && run.ExitedOn != nil {
+				a.durations = append(a.durations, run.ExitedOn.Sub(*run.CreatedOn))
+			}
+		case runs.ExitTypeInterrupted:
+			report.Interrupted++
+		case runs.ExitTypeExpired:
+			report.Expired++
+		default:
+			report.Active++
+		}
+
+		for key, value := range run.Values {
+			counter, ok := a.byKey[key]
+			if !ok {
+				continue
+			}
+			counter.add(value.Category)
+		}
+
+		seen := make(map[string]bool, len(run.Path))
+		for _, step := range run.Path {
+			if !seen[step.Node] {
+				seen[step.Node] = true
+				a.visits[step.Node]++
+			}
+		}
+		if len(run.Path) > 0 && (run.ExitType == runs.ExitTypeInterrupted || run.ExitType == runs.ExitTypeExpired) {
+			a.dropOffs[run.Path[len(run.Path)-1].Node]++
+		}
+	}
+}
+
+// Report returns the Report of the runs added so far
+func (a *Accumulator) Report() *Report {
+	report := *a.report
+	report.CompletionRate = rate(report.Completed, report.Runs)
+	report.InterruptionRate = rate(report.Interrupted, report.Runs)
+	report.ExpiryRate = rate(report.Expired, report.Runs)
+	report.MedianTimeToComplete = median(a.durations)
+
+	report.Results = make([]ResultDistribution, len(a.distributions))
+	for i, distribution := range a.distributions {
+		a.byKey[distribution.Key].fill(&distribution)
+		report.Results[i] = distribution
+	}
+
+	for node, count := range a.visits {
+		report.DropOff = append(report.DropOff, NodeDropOff{
+			Node:     node,
+			Visits:   count,
+			DropOffs: a.dropOffs[node],
+			Rate:     rate(a.dropOffs[node], count),
+		})
+	}
+	sort.Slice(report.DropOff, func(i, j int) bool {
+		a, b := report.DropOff[i], report.DropOff[j]
+		if a.Visits != b.Visits {
+			return a.Visits > b.Visits
+		}
+		return a.Node < b.Node
+	})
+
+	return &report
+}
+
+type categoryCounter struct {
+	order  []string
+	counts map[string]int
+	total  int
+}
+
+func newCategoryCounter(categories []string) *categoryCounter {
+	counter := &categoryCounter{counts: make(map[string]int, len(categories))}
+	for _, category := range categories {
+		counter.order = append(counter.order, category)
+		counter.counts[category] = 0
+	}
+	return counter
+}
+
+func (c *categoryCounter) add(category string) {
+	if _, ok := c.counts[category]; !ok {
+		c.order = append(c.order, category)
+	}
+	c.counts[category]++
+	c.total++
+}
+
+func (c *categoryCounter) fill(distribution *ResultDistribution) {
+	distribution.Total = c.total
+	for _, category := range c.order {
+		distribution.Categories = append(distribution.Categories, CategoryCount{
+			Name:  category,
+			Count: c.counts[category],
+			Rate:  rate(c.counts[category], c.total),
+		})
+	}
+}
+
+func rate(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
+
+func median(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	middle := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return (durations[middle-1] + durations[middle]) / 2
+	}
+	return durations[middle]
+}
@@ -0,0 +1,161 @@
+package analytics
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rasoro/rapidpro-api-go/v2/flows"
+	"github.com/rasoro/rapidpro-api-go/v2/runs"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeTestData(t *testing.T) (*flows.Flow, []runs.Run) {
+	flowsResponse := &flows.Response{}
+	assert.NoError(t, json.Unmarshal([]byte(testFlows), flowsResponse))
+	runsResponse := &runs.Response{}
+	assert.NoError(t, json.Unmarshal([]byte(testRuns), runsResponse))
+	return &flowsResponse.Results[0], runsResponse.Results
+}
+
+func TestCompute(t *testing.T) {
+	flow, flowRuns := decodeTestData(t)
+	report := Compute(flow, flowRuns)
+
+	assert.Equal(t, "Favorite Color", report.FlowName)
+	assert.Equal(t, 5, report.Runs)
+	assert.Equal(t, 2, report.Completed)
+	assert.Equal(t, 1, report.Interrupted)
+	assert.Equal(t, 1, report.Expired)
+	assert.Equal(t, 1, report.Active)
+	assert.Equal(t, 0.4, report.CompletionRate)
+	assert.Equal(t, 0.2, report.InterruptionRate)
+	assert.Equal(t, 0.2, report.ExpiryRate)
+	assert.Equal(t, 90*time.Second, report.MedianTimeToComplete)
+
+	assert.Equal(t, []ResultDistribution{
+		{
+			Key:   "color",
+			Name:  "Color",
+			Total: 3,
+			Categories: []CategoryCount{
+				{Name: "Red", Count: 2, Rate: rate(2, 3)},
+				{Name: "Blue", Count: 0, Rate: 0},
+				{Name: "Other", Count: 0, Rate: 0},
+				{Name: "Purple", Count: 1, Rate: rate(1, 3)},
+			},
+		},
+	}, report.Results)
+
+	assert.Equal(t, []NodeDropOff{
+		{Node: "node-ask", Visits: 5, DropOffs: 1, Rate: 0.2},
+		{Node: "node-thanks", Visits: 3, DropOffs: 1, Rate: 1.0 / 3},
+	}, report.DropOff)
+}
+
+func TestAccumulator(t *testing.T) {
+	flow, flowRuns := decodeTestData(t)
+	accumulator := NewAccumulator(flow)
+	accumulator.Add(flowRuns[:2])
+	assert.Equal(t, 2, accumulator.Report().Runs)
+
+	// runs are added a page at a time, with the same report as computing it from all of them
+	accumulator.Add(flowRuns[2:])
+	assert.Equal(t, Compute(flow, flowRuns), accumulator.Report())
+	assert.Equal(t, accumulator.Report(), accumulator.Report())
+}
+
+func TestComputeWithoutRuns(t *testing.T) {
+	flow, _ := decodeTestData(t)
+	report := Compute(flow, nil)
+
+	assert.Equal(t, 0, report.Runs)
+	assert.Equal(t, float64(0), report.CompletionRate)
+	assert.Equal(t, time.Duration(0), report.MedianTimeToComplete)
+	assert.Equal(t, 3, len(report.Results[0].Categories))
+	assert.Empty(t, report.DropOff)
+}
+
+func TestMedian(t *testing.T) {
+	assert.Equal(t, 2*time.Second, median([]time.Duration{3 * time.Second, time.Second, 2 * time.Second}))
+	assert.Equal(t, 1500*time.Millisecond, median([]time.Duration{2 * time.Second, time.Second}))
+}
+
+var testFlows = `{
+	"next": null,
+	"previous": null,
+	"results": [
+		{
+			"uuid": "f5901b62-ba76-4003-9c62-72fdacc1b7b7",
+			"name": "Favorite Color",
+			"type": "message",
+			"archived": false,
+			"labels": [],
+			"expires": 600,
+			"runs": {"active": 1, "completed": 2, "interrupted": 1, "expired": 1},
+			"results": [
+				{"key": "color", "name": "Color", "categories": ["Red", "Blue", "Other"], "node_uuids": ["node-ask"]}
+			],
+			"parent_refs": [],
+			"created_on": "2016-01-06T15:33:00.813162Z",
+			"modified_on": "2017-01-07T13:14:00.453567Z"
+		}
+	]
+}`
+
+var testRuns = `{
+	"next": null,
+	"previous": null,
+	"results": [
+		{
+			"id": 1,
+			"flow": {"uuid": "f5901b62-ba76-4003-9c62-72fdacc1b7b7", "name": "Favorite Color"},
+			"path": [{"node": "node-ask"}, {"node": "node-ask"}, {"node": "node-thanks"}],
+			"values": {"color": {"value": "red", "category": "Red"}},
+			"created_on": "2022-01-01T10:00:00Z",
+			"exited_on": "2022-01-01T10:01:00Z",
+			"exit_type": "completed"
+		},
+		{
+			"id": 2,
+			"flow": {"uuid": "f5901b62-ba76-4003-9c62-72fdacc1b7b7", "name": "Favorite Color"},
+			"path": [{"node": "node-ask"}, {"node": "node-thanks"}],
+			"values": {"color": {"value": "red", "category": "Red"}, "unknown": {"value": "x", "category": "X"}},
+			"created_on": "2022-01-01T10:00:00Z",
+			"exited_on": "2022-01-01T10:02:00Z",
+			"exit_type": "completed"
+		},
+		{
+			"id": 3,
+			"flow": {"uuid": "f5901b62-ba76-4003-9c62-72fdacc1b7b7", "name": "Favorite Color"},
+			"path": [{"node": "node-ask"}, {"node": "node-thanks"}],
+			"values": {"color": {"value": "purple", "category": "Purple"}},
+			"created_on": "2022-01-01T10:00:00Z",
+			"exited_on": "2022-01-01T10:02:00Z",
+			"exit_type": "interrupted"
+		},
+		{
+			"id": 4,
+			"flow": {"uuid": "f5901b62-ba76-4003-9c62-72fdacc1b7b7", "name": "Favorite Color"},
+			"path": [{"node": "node-ask"}],
+			"values": {},
+			"created_on": "2022-01-01T10:00:00Z",
+			"exited_on": "2022-01-08T10:00:00Z",
+			"exit_type": "expired"
+		},
+		{
+			"id": 5,
+			"flow": {"uuid": "f5901b62-ba76-4003-9c62-72fdacc1b7b7", "name": "Favorite Color"},
+			"path": [{"node": "node-ask"}],
+			"values": {},
+			"created_on": "2022-01-01T10:00:00Z",
+			"exit_type": null
+		},
+		{
+			"id": 6,
+			"flow": {"uuid": "00000000-0000-0000-0000-000000000000", "name": "Other Flow"},
+			"path": [{"node": "node-other"}],
+			"exit_type": "completed"
+		}
+	]
+}`
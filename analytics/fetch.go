@@ -0,0 +1,45 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/rasoro/rapidpro-api-go/v2/flows"
+	"github.com/rasoro/rapidpro-api-go/v2/runs"
+)
+
+// ErrFlowNotFound is returned by Analyze when no flow has the given uuid
+var ErrFlowNotFound = errors.New("flow not found")
+
+// Analyze fetches the flow and pages through its runs modified in the window, computing their Report as it
+// goes. A nil after or before leaves that side of the window open.
+func Analyze(flowsService *flows.ApiService, runsService *runs.ApiService, flowUUID string, after, before *time.Time) (*Report, error) {
+	flowsResponse, err := flowsService.Get(&flows.QueryParams{UUID: flowUUID})
+	if err != nil {
+		return nil, err
+	}
+	if len(flowsResponse.Results) == 0 {
+		return nil, ErrFlowNotFound
+	}
+
+	accumulator := NewAccumulator(&flowsResponse.Results[0])
+	params := &runs.QueryParams{Flow: flowUUID, After: after, Before: before}
+	for {
+		response, err := runsService.Get(params)
+		if err != nil {
+			return nil, err
+		}
+		accumulator.Add(response.Results)
+
+		params.Cursor = response.NextCursor()
+		if params.Cursor == "" {
+			break
+		}
+	}
+
+	report := accumulator.Report()
+	report.After = after
+	report.Before = before
+	return report, nil
+}
@@ -0,0 +1,65 @@
+package analytics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/rasoro/rapidpro-api-go/v2/flows"
+	"github.com/rasoro/rapidpro-api-go/v2/runs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyze(t *testing.T) {
+	var mockServer *httptest.Server
+	mockServer = httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			switch r.URL.Path {
+			case "/v2/flows.json":
+				if query.Get("uuid") != "f5901b62-ba76-4003-9c62-72fdacc1b7b7" {
+					w.Write([]byte(`{"next": null, "previous": null, "results": []}`))
+					return
+				}
+				w.Write([]byte(testFlows))
+			case "/v2/runs.json":
+				assert.Equal(t, "f5901b62-ba76-4003-9c62-72fdacc1b7b7", query.Get("flow"))
+				assert.Equal(t, "2022-01-01T00:00:00Z", query.Get("after"))
+				if query.Get("cursor") == "" {
+					// the first page only has the first run and links to the rest
+					first := strings.Index(testRuns, `,
+		{
+			"id": 2,`)
+					page := strings.Replace(testRuns[:first], `"next": null`, `"next": "`+mockServer.URL+`/v2/runs.json?cursor=page2"`, 1)
+					w.Write([]byte(page + "]}"))
+					return
+				}
+				assert.Equal(t, "page2", query.Get("cursor"))
+				second := strings.Index(testRuns, `{
+			"id": 2,`)
+				w.Write([]byte(`{"next": null, "previous": null, "results": [` + testRuns[second:]))
+			}
+		}))
+	defer mockServer.Close()
+
+	defaultClient := &client.Client{
+		Credentials: &client.Credentials{Token: "token123"},
+	}
+	requestHandler := client.NewRequestHandler(defaultClient)
+	flowsService := flows.NewService(requestHandler, mockServer.URL)
+	runsService := runs.NewService(requestHandler, mockServer.URL)
+
+	after := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	report, err := Analyze(flowsService, runsService, "f5901b62-ba76-4003-9c62-72fdacc1b7b7", &after, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &after, report.After)
+	assert.Nil(t, report.Before)
+	assert.Equal(t, 5, report.Runs)
+	assert.Equal(t, 2, report.Completed)
+
+	_, err = Analyze(flowsService, runsService, "00000000-0000-0000-0000-000000000000", nil, nil)
+	assert.Equal(t, ErrFlowNotFound, err)
+}
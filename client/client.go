@@ -3,6 +3,7 @@ package client
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"runtime"
@@ -57,15 +58,25 @@ func (c *Client) SendRequest(
 	body interface{},
 	headers map[string]interface{},
 ) (*http.Response, error) {
-	reader := &strings.Reader{}
+	var reader io.Reader = &strings.Reader{}
+	contentType := "application/json"
 	goVersion := runtime.Version()
 
 	if method == http.MethodPost {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return nil, err
+		if multipartBody, ok := body.(*MultipartBody); ok {
+			buf, formContentType, err := multipartBody.encode()
+			if err != nil {
+				return nil, err
+			}
+			reader = buf
+			contentType = formContentType
+		} else {
+			jsonBody, err := json.Marshal(body)
+			if err != nil {
+				return nil, err
+			}
+			reader = strings.NewReader(string(jsonBody))
 		}
-		reader = strings.NewReader(string(jsonBody))
 	}
 
 	req, err := http.NewRequest(method, rawURL, reader)
@@ -88,7 +99,7 @@ func (c *Client) SendRequest(
 	req.Header.Add("User-Agent", userAgent)
 
 	if method == http.MethodPost {
-		req.Header.Add("Content-Type", "aplication/json")
+		req.Header.Add("Content-Type", contentType)
 	}
 
 	for k, v := range headers {
@@ -68,6 +68,7 @@ func TestClient_SendRequestWithData(t *testing.T) {
 			case http.MethodGet:
 				value = request.FormValue("foo")
 			case http.MethodPost:
+				assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
 				var body map[string]string
 				decoder := json.NewDecoder(request.Body)
 				err := decoder.Decode(&body)
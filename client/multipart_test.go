@@ -0,0 +1,69 @@
+package client_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_SendRequestWithMultipartBody(t *testing.T) {
+	multipartServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.True(t, strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data; boundary="))
+			assert.NoError(t, r.ParseMultipartForm(1024))
+			assert.Equal(t, "bar", r.FormValue("foo"))
+
+			file, header, err := r.FormFile("file")
+			assert.NoError(t, err)
+			assert.Equal(t, `my "photo".jpg`, header.Filename)
+			assert.Equal(t, "image/jpeg", header.Header.Get("Content-Type"))
+			content, _ := ioutil.ReadAll(file)
+			assert.Equal(t, "jpegdata", string(content))
+		}))
+	defer multipartServer.Close()
+
+	body := &rapidpro.MultipartBody{
+		Fields: map[string]string{"foo": "bar"},
+		Files: []rapidpro.MultipartFile{
+			{FieldName: "file", FileName: `my "photo".jpg`, ContentType: "image/jpeg", Reader: strings.NewReader("jpegdata")},
+		},
+	}
+	resp, err := testClient.SendRequest(http.MethodPost, multipartServer.URL, nil, body, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestClient_SendRequestWithMultipartBodyDefaultContentType(t *testing.T) {
+	multipartServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, header, err := r.FormFile("file")
+			assert.NoError(t, err)
+			assert.Equal(t, "application/octet-stream", header.Header.Get("Content-Type"))
+		}))
+	defer multipartServer.Close()
+
+	body := &rapidpro.MultipartBody{
+		Files: []rapidpro.MultipartFile{{FieldName: "file", FileName: "data.bin", Reader: strings.NewReader("data")}},
+	}
+	_, err := testClient.SendRequest(http.MethodPost, multipartServer.URL, nil, body, nil)
+	assert.NoError(t, err)
+}
+
+type errReader struct{}
+
+func (errReader) Read(p []byte) (int, error) { return 0, errors.New("read error") }
+
+func TestClient_SendRequestWithMultipartBodyReadError(t *testing.T) {
+	body := &rapidpro.MultipartBody{
+		Files: []rapidpro.MultipartFile{{FieldName: "file", FileName: "data.bin", Reader: errReader{}}},
+	}
+	resp, err := testClient.SendRequest(http.MethodPost, mockServer.URL, nil, body, nil)
+	assert.EqualError(t, err, "read error")
+	assert.Nil(t, resp)
+}
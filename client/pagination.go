@@ -0,0 +1,17 @@
+package client
+
+import "net/url"
+
+// Cursor returns the cursor query parameter of a next or previous page URL as found in list responses,
+// or an empty string when there is no such page
+func Cursor(pageURL interface{}) string {
+	rawURL, ok := pageURL.(string)
+	if !ok || rawURL == "" {
+		return ""
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("cursor")
+}
@@ -0,0 +1,15 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	assert.Equal(t, "cD0yMDE1LTExLTExKzExJTNBM40NjQlMkIwMCUzRv", client.Cursor("http://example.com/api/v2/runs.json?cursor=cD0yMDE1LTExLTExKzExJTNBM40NjQlMkIwMCUzRv"))
+	assert.Equal(t, "", client.Cursor("http://example.com/api/v2/runs.json"))
+	assert.Equal(t, "", client.Cursor(nil))
+	assert.Equal(t, "", client.Cursor("%zz"))
+}
@@ -0,0 +1,214 @@
+// Package diagram renders exported flow definitions as Mermaid and Graphviz diagrams.
+package diagram
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rasoro/rapidpro-api-go/v2/definitions"
+	"github.com/rasoro/rapidpro-api-go/v2/flows"
+	"github.com/rasoro/rapidpro-api-go/v2/runs"
+)
+
+// maxSummaryLength is the number of characters action summaries are truncated to
+const maxSummaryLength = 40
+
+// Segment is a step of runs from one node to the next
+type Segment struct {
+	From string
+	To   string
+}
+
+// Options configures what is overlaid on a diagram
+type Options struct {
+	// Flow overlays the run totals of the flow in the diagram title
+	Flow *flows.Flow
+	// Segments overlays the number of runs that went through each edge as its weight
+	Segments map[Segment]int
+}
+
+// SegmentsFromRuns counts how many times runs stepped from one node to the next
+func SegmentsFromRuns(flowRuns []runs.Run) map[Segment]int {
+	segments := make(map[Segment]int)
+	for _, run := range flowRuns {
+		for i := 1; i < len(run.Path); i++ {
+			segments[Segment{From: run.Path[i-1].Node, To: run.Path[i].Node}]++
+		}
+	}
+	return segments
+}
+
+// graph is the renderer independent layout of a flow
+type graph struct {
+	title string
+	nodes []graphNode
+	edges []graphEdge
+}
+
+type graphNode struct {
+	id    string
+	lines []string
+}
+
+type graphEdge struct {
+	from   string
+	to     string
+	label  string
+	weight int
+}
+
+func newGraph(flow *definitions.Flow, opts *Options) *graph {
+	if opts == nil {
+		opts = &Options{}
+	}
+	g := &graph{title: flow.Name}
+	if opts.Flow != nil {
+		r := opts.Flow.Runs
+		g.title = fmt.Sprintf("%s (active: %d, completed: %d, interrupted: %d, expired: %d)",
+			flow.Name, r.Active, r.Completed, r.Interrupted, r.Expired)
+	}
+
+	ids := make(map[string]string, len(flow.Nodes))
+	for i, node := range flow.Nodes {
+		ids[node.UUID] = fmt.Sprintf("n%d", i)
+	}
+
+	for _, node := range flow.Nodes {
+		gn := graphNode{id: ids[node.UUID]}
+		for _, action := range node.Actions {
+			gn.lines = append(gn.lines, summarizeAction(&action))
+		}
+		if node.Router != nil {
+			gn.lines = append(gn.lines, summarizeRouter(node.Router))
+		}
+		if len(gn.lines) == 0 {
+			gn.lines = []string{"(empty)"}
+		}
+		g.nodes = append(g.nodes, gn)
+
+		// run paths only record nodes, so exits leading to the same node are drawn as a single edge
+		// for the count of runs between the nodes to be shown once
+		edges := make(map[string]int) // destination uuid -> index in g.edges
+		for _, exit := range node.Exits {
+			to, ok := ids[exit.DestinationUUID]
+			if !ok {
+				continue
+			}
+			label := ""
+			if node.Router != nil {
+				label = exitLabel(node.Router, exit.UUID)
+			}
+			if i, ok := edges[exit.DestinationUUID]; ok {
+				g.edges[i].label = joinLabels(g.edges[i].label, label)
+				continue
+			}
+			edges[exit.DestinationUUID] = len(g.edges)
+			g.edges = append(g.edges, graphEdge{from: gn.id, to: to, label: label})
+		}
+
+		if opts.Segments != nil {
+			for destination, i := range edges {
+				edge := &g.edges[i]
+				edge.weight = opts.Segments[Segment{From: node.UUID, To: destination}]
+				if edge.weight > 0 {
+					edge.label = strings.TrimSpace(fmt.Sprintf("%s (%d)", edge.label, edge.weight))
+				}
+			}
+		}
+	}
+	return g
+}
+
+// joinLabels joins the labels of exits drawn as the same edge
+func joinLabels(a, b string) string {
+	if a == "" || b == "" {
+		return a + b
+	}
+	return a + ", " + b
+}
+
+// exitLabel returns the names of the categories of a router that lead to an exit
+func exitLabel(router *definitions.Router, exitUUID string) string {
+	var names []string
+	for _, category := range router.Categories {
+		if category.ExitUUID == exitUUID {
+			names = append(names, category.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func summarizeAction(action *definitions.Action) string {
+	var detail string
+	switch action.Type {
+	case definitions.ActionTypeSendMsg, definitions.ActionTypeSendBroadcast, definitions.ActionTypeSayMsg:
+		detail = action.Text
+	case definitions.ActionTypePlayAudio:
+		detail = action.AudioURL
+	case definitions.ActionTypeSendEmail:
+		detail = action.Subject
+	case definitions.ActionTypeSetContactField:
+		if action.Field != nil {
+			detail = action.Field.Key + " = " + action.Value
+		}
+	case definitions.ActionTypeSetContactName:
+		detail = action.Name
+	case definitions.ActionTypeSetContactLanguage:
+		detail = action.Language
+	case definitions.ActionTypeSetRunResult:
+		detail = action.Name + " = " + action.Value
+	case definitions.ActionTypeAddContactGroups, definitions.ActionTypeRemoveContactGroups:
+		names := make([]string, 0, len(action.Groups))
+		for _, group := range action.Groups {
+			names = append(names, group.Name)
+		}
+		detail = strings.Join(names, ", ")
+	case definitions.ActionTypeEnterFlow, definitions.ActionTypeStartSession:
+		if action.Flow != nil {
+			detail = action.Flow.Name
+		}
+	case definitions.ActionTypeCallWebhook:
+		detail = strings.TrimSpace(action.Method + " " + action.URL)
+	case definitions.ActionTypeCallResthook:
+		detail = action.Resthook
+	case definitions.ActionTypeCallClassifier:
+		if action.Classifier != nil {
+			detail = action.Classifier.Name
+		}
+	case definitions.ActionTypeOpenTicket:
+		if action.Topic != nil {
+			detail = action.Topic.Name
+		}
+	}
+
+	if detail == "" {
+		return action.Type
+	}
+	return action.Type + ": " + truncate(detail)
+}
+
+func summarizeRouter(router *definitions.Router) string {
+	var summary string
+	switch {
+	case router.Wait != nil:
+		summary = "wait for " + router.Wait.Type
+	case router.Type == definitions.RouterTypeRandom:
+		summary = "random split"
+	default:
+		summary = "split by " + router.Operand
+	}
+	if router.ResultName != "" {
+		summary += ", save as " + router.ResultName
+	}
+	return truncate(summary)
+}
+
+func truncate(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= maxSummaryLength {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:maxSummaryLength-1]) + "…"
+}
@@ -0,0 +1,144 @@
+package diagram
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rasoro/rapidpro-api-go/v2/definitions"
+	"github.com/rasoro/rapidpro-api-go/v2/flows"
+	"github.com/rasoro/rapidpro-api-go/v2/runs"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeFlow(t *testing.T) *definitions.Flow {
+	flow := &definitions.Flow{}
+	assert.NoError(t, json.Unmarshal([]byte(testFlow), flow))
+	return flow
+}
+
+func TestMermaid(t *testing.T) {
+	assert.Equal(t, `---
+title: "Favorite Color"
+---
+flowchart TD
+    n0["send_msg: What is your #quot;favorite#quot; color?<br/>wait for msg, save as Color"]
+    n1["send_msg: Thanks, I love that color too!"]
+    n0 -->|"Red, Blue"| n1
+    n0 -->|"Other"| n0
+`, Mermaid(decodeFlow(t), nil))
+}
+
+func TestDOT(t *testing.T) {
+	flow := decodeFlow(t)
+	opts := &Options{
+		Segments: map[Segment]int{
+			{From: "node-ask", To: "node-thanks"}: 4,
+			{From: "node-ask", To: "node-ask"}:    1,
+		},
+	}
+
+	assert.Equal(t, `digraph flow {
+    label="Favorite Color";
+    labelloc=t;
+    node [shape=box];
+    n0 [label="send_msg: What is your \"favorite\" color?\lwait for msg, save as Color\l"];
+    n1 [label="send_msg: Thanks, I love that color too!\l"];
+    n0 -> n1 [label="Red, Blue (4)", penwidth=5.0];
+    n0 -> n0 [label="Other (1)", penwidth=2.0];
+}
+`, DOT(flow, opts))
+}
+
+func TestOverlayRuns(t *testing.T) {
+	flowRuns := []runs.Run{
+		{Path: []runs.Step{{Node: "node-ask"}, {Node: "node-ask"}, {Node: "node-thanks"}}},
+		{Path: []runs.Step{{Node: "node-ask"}, {Node: "node-thanks"}}},
+		{Path: []runs.Step{{Node: "node-ask"}}},
+	}
+	segments := SegmentsFromRuns(flowRuns)
+	assert.Equal(t, map[Segment]int{
+		{From: "node-ask", To: "node-ask"}:    1,
+		{From: "node-ask", To: "node-thanks"}: 2,
+	}, segments)
+
+	apiFlow := &flows.Flow{}
+	apiFlow.Runs.Active = 1
+	apiFlow.Runs.Completed = 2
+
+	diagram := Mermaid(decodeFlow(t), &Options{Flow: apiFlow, Segments: segments})
+	assert.Contains(t, diagram, `title: "Favorite Color (active: 1, completed: 2, interrupted: 0, expired: 0)"`)
+	assert.Contains(t, diagram, `n0 -->|"Red, Blue (2)"| n1`)
+	assert.Contains(t, diagram, `n0 -->|"Other (1)"| n0`)
+}
+
+func TestParallelExits(t *testing.T) {
+	flow := decodeFlow(t)
+	flow.Nodes[0].Exits[1].DestinationUUID = "node-thanks"
+	opts := &Options{
+		Segments: map[Segment]int{{From: "node-ask", To: "node-thanks"}: 10},
+	}
+
+	diagram := Mermaid(flow, opts)
+	assert.Contains(t, diagram, `n0 -->|"Red, Blue, Other (10)"| n1`)
+	assert.Equal(t, 1, strings.Count(diagram, "-->"))
+}
+
+func TestSummarizeAction(t *testing.T) {
+	assert.Equal(t, "call_webhook: POST https://example.com/hook", summarizeAction(&definitions.Action{
+		Type: definitions.ActionTypeCallWebhook, Method: "POST", URL: "https://example.com/hook",
+	}))
+	assert.Equal(t, "add_contact_groups: Customers, Testers", summarizeAction(&definitions.Action{
+		Type:   definitions.ActionTypeAddContactGroups,
+		Groups: []definitions.GroupReference{{Name: "Customers"}, {Name: "Testers"}},
+	}))
+	assert.Equal(t, "add_input_labels", summarizeAction(&definitions.Action{Type: definitions.ActionTypeAddInputLabels}))
+	assert.Equal(t, "send_msg: This is a very long message that will b…", summarizeAction(&definitions.Action{
+		Type: definitions.ActionTypeSendMsg, Text: "This is a very long message that will be\ntruncated",
+	}))
+}
+
+var testFlow = `{
+	"uuid": "f5901b62-ba76-4003-9c62-72fdacc1b7b7",
+	"name": "Favorite Color",
+	"spec_version": "13.1.0",
+	"language": "eng",
+	"type": "messaging",
+	"nodes": [
+		{
+			"uuid": "node-ask",
+			"actions": [
+				{"uuid": "action-ask", "type": "send_msg", "text": "What is your \"favorite\" color?"}
+			],
+			"router": {
+				"type": "switch",
+				"operand": "@input.text",
+				"result_name": "Color",
+				"wait": {"type": "msg"},
+				"cases": [
+					{"uuid": "case-red", "type": "has_any_word", "arguments": ["red"], "category_uuid": "category-red"},
+					{"uuid": "case-blue", "type": "has_any_word", "arguments": ["blue"], "category_uuid": "category-blue"}
+				],
+				"categories": [
+					{"uuid": "category-red", "name": "Red", "exit_uuid": "exit-color"},
+					{"uuid": "category-blue", "name": "Blue", "exit_uuid": "exit-color"},
+					{"uuid": "category-other", "name": "Other", "exit_uuid": "exit-other"}
+				],
+				"default_category_uuid": "category-other"
+			},
+			"exits": [
+				{"uuid": "exit-color", "destination_uuid": "node-thanks"},
+				{"uuid": "exit-other", "destination_uuid": "node-ask"}
+			]
+		},
+		{
+			"uuid": "node-thanks",
+			"actions": [
+				{"uuid": "action-thanks", "type": "send_msg", "text": "Thanks, I love that color too!"}
+			],
+			"exits": [
+				{"uuid": "exit-end", "destination_uuid": null}
+			]
+		}
+	]
+}`
@@ -0,0 +1,52 @@
+package diagram
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/rasoro/rapidpro-api-go/v2/definitions"
+)
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// DOT renders the flow as a Graphviz digraph, edges with more runs are drawn thicker
+func DOT(flow *definitions.Flow, opts *Options) string {
+	g := newGraph(flow, opts)
+
+	maxWeight := 0
+	for _, edge := range g.edges {
+		if edge.weight > maxWeight {
+			maxWeight = edge.weight
+		}
+	}
+
+	b := &strings.Builder{}
+	b.WriteString("digraph flow {\n")
+	fmt.Fprintf(b, "    label=\"%s\";\n    labelloc=t;\n", dotEscaper.Replace(g.title))
+	b.WriteString("    node [shape=box];\n")
+	for _, node := range g.nodes {
+		lines := make([]string, len(node.lines))
+		for i, line := range node.lines {
+			lines[i] = dotEscaper.Replace(line)
+		}
+		fmt.Fprintf(b, "    %s [label=\"%s\\l\"];\n", node.id, strings.Join(lines, `\l`))
+	}
+	for _, edge := range g.edges {
+		var attrs []string
+		if edge.label != "" {
+			attrs = append(attrs, fmt.Sprintf("label=\"%s\"", dotEscaper.Replace(edge.label)))
+		}
+		if edge.weight > 0 {
+			penwidth := 1 + 4*float64(edge.weight)/float64(maxWeight)
+			attrs = append(attrs, fmt.Sprintf("penwidth=%.1f", math.Round(penwidth*10)/10))
+		}
+		if len(attrs) == 0 {
+			fmt.Fprintf(b, "    %s -> %s;\n", edge.from, edge.to)
+			continue
+		}
+		fmt.Fprintf(b, "    %s -> %s [%s];\n", edge.from, edge.to, strings.Join(attrs, ", "))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
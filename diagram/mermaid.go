@@ -0,0 +1,34 @@
+package diagram
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rasoro/rapidpro-api-go/v2/definitions"
+)
+
+var mermaidEscaper = strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;")
+
+// Mermaid renders the flow as a Mermaid flowchart
+func Mermaid(flow *definitions.Flow, opts *Options) string {
+	g := newGraph(flow, opts)
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "---\ntitle: \"%s\"\n---\n", mermaidEscaper.Replace(g.title))
+	b.WriteString("flowchart TD\n")
+	for _, node := range g.nodes {
+		lines := make([]string, len(node.lines))
+		for i, line := range node.lines {
+			lines[i] = mermaidEscaper.Replace(line)
+		}
+		fmt.Fprintf(b, "    %s[\"%s\"]\n", node.id, strings.Join(lines, "<br/>"))
+	}
+	for _, edge := range g.edges {
+		if edge.label == "" {
+			fmt.Fprintf(b, "    %s --> %s\n", edge.from, edge.to)
+			continue
+		}
+		fmt.Fprintf(b, "    %s -->|\"%s\"| %s\n", edge.from, mermaidEscaper.Replace(edge.label), edge.to)
+	}
+	return b.String()
+}
@@ -0,0 +1,473 @@
+// Package diff compares versions of a flow definition and reports what changed in terms of its
+// nodes, actions, routing, translations and results rather than its raw JSON.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rasoro/rapidpro-api-go/v2/definitions"
+)
+
+// Change types
+const (
+	Added   = "added"
+	Removed = "removed"
+	Changed = "changed"
+)
+
+// Elements of a flow a change can be about
+const (
+	ElementFlow        = "flow"
+	ElementNode        = "node"
+	ElementAction      = "action"
+	ElementRouter      = "router"
+	ElementCase        = "case"
+	ElementCategory    = "category"
+	ElementExit        = "exit"
+	ElementTranslation = "translation"
+	ElementResult      = "result"
+)
+
+// Change is a single difference between two versions of a flow
+type Change struct {
+	Type     string `json:"type"`
+	Element  string `json:"element"`
+	Node     string `json:"node,omitempty"`
+	UUID     string `json:"uuid,omitempty"`
+	Language string `json:"language,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Old      string `json:"old,omitempty"`
+	New      string `json:"new,omitempty"`
+}
+
+func (c Change) String() string {
+	symbol := map[string]string{Added: "+", Removed: "-", Changed: "~"}[c.Type]
+
+	subject := c.Element
+	if c.Language != "" {
+		subject += " " + c.Language
+	}
+	if c.UUID != "" {
+		subject += " " + c.UUID
+	}
+	if c.Node != "" && c.Element != ElementNode {
+		subject += " in node " + c.Node
+	}
+	if c.Field != "" {
+		subject += " " + c.Field
+	}
+
+	switch c.Type {
+	case Added:
+		return fmt.Sprintf("%s %s: %s", symbol, subject, c.New)
+	case Removed:
+		return fmt.Sprintf("%s %s: %s", symbol, subject, c.Old)
+	}
+	return fmt.Sprintf("%s %s: %s → %s", symbol, subject, c.Old, c.New)
+}
+
+// Diff is the list of changes between two versions of a flow
+type Diff struct {
+	Flow        string   `json:"flow"`
+	Name        string   `json:"name"`
+	OldRevision int      `json:"old_revision"`
+	NewRevision int      `json:"new_revision"`
+	Changes     []Change `json:"changes"`
+}
+
+// Empty returns whether the versions are semantically the same
+func (d *Diff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// String renders the diff as text, one change per line
+func (d *Diff) String() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "Flow %s (%s) revision %d → %d\n", d.Name, d.Flow, d.OldRevision, d.NewRevision)
+	for _, change := range d.Changes {
+		fmt.Fprintf(b, "  %s\n", change)
+	}
+	return b.String()
+}
+
+// CompareExports compares the flows of two exports matching them by uuid, flows that are only
+// in one of them are reported as added or removed
+func CompareExports(old, new *definitions.Export) []*Diff {
+	var diffs []*Diff
+	for i := range old.Flows {
+		oldFlow := &old.Flows[i]
+		newFlow := new.Flow(oldFlow.UUID)
+		if newFlow == nil {
+			d := &Diff{Flow: oldFlow.UUID, Name: oldFlow.Name, OldRevision: oldFlow.Revision}
+			d.Changes = []Change{{Type: Removed, Element: ElementFlow, UUID: oldFlow.UUID, Old: oldFlow.Name}}
+			diffs = append(diffs, d)
+			continue
+		}
+		diffs = append(diffs, Compare(oldFlow, newFlow))
+	}
+	for i := range new.Flows {
+		newFlow := &new.Flows[i]
+		if old.Flow(newFlow.UUID) == nil {
+			d := &Diff{Flow: newFlow.UUID, Name: newFlow.Name, NewRevision: newFlow.Revision}
+			d.Changes = []Change{{Type: Added, Element: ElementFlow, UUID: newFlow.UUID, New: newFlow.Name}}
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs
+}
+
+// Compare returns the changes from the old to the new version of a flow
+func Compare(old, new *definitions.Flow) *Diff {
+	c := &comparer{}
+	c.compareFlow(old, new)
+	c.compareNodes(old, new)
+	c.compareTranslations(old.Localization, new.Localization)
+	c.compareResults(old, new)
+
+	return &Diff{
+		Flow:        new.UUID,
+		Name:        new.Name,
+		OldRevision: old.Revision,
+		NewRevision: new.Revision,
+		Changes:     c.changes,
+	}
+}
+
+type comparer struct {
+	changes []Change
+}
+
+func (c *comparer) add(change Change) {
+	c.changes = append(c.changes, change)
+}
+
+// compareFields reports the changed fields of two versions of an element
+func (c *comparer) compareFields(element, node, uuid string, old, new interface{}) {
+	oldFields, newFields := fields(old), fields(new)
+	keys := make([]string, 0, len(oldFields)+len(newFields))
+	for key := range oldFields {
+		keys = append(keys, key)
+	}
+	for key := range newFields {
+		if _, ok := oldFields[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if oldFields[key] != newFields[key] {
+			c.add(Change{Type: Changed, Element: element, Node: node, UUID: uuid, Field: key, Old: oldFields[key], New: newFields[key]})
+		}
+	}
+}
+
+func (c *comparer) compareFlow(old, new *definitions.Flow) {
+	props := func(f *definitions.Flow) interface{} {
+		return map[string]interface{}{
+			"name":                 f.Name,
+			"type":                 f.Type,
+			"language":             f.Language,
+			"expire_after_minutes": f.ExpireAfterMinutes,
+		}
+	}
+	c.compareFields(ElementFlow, "", "", props(old), props(new))
+}
+
+func (c *comparer) compareNodes(old, new *definitions.Flow) {
+	for _, oldNode := range old.Nodes {
+		newNode := new.Node(oldNode.UUID)
+		if newNode == nil {
+			c.add(Change{Type: Removed, Element: ElementNode, Node: oldNode.UUID, UUID: oldNode.UUID, Old: summarizeNode(&oldNode)})
+			continue
+		}
+		c.compareNode(&oldNode, newNode)
+	}
+	for _, newNode := range new.Nodes {
+		if old.Node(newNode.UUID) == nil {
+			c.add(Change{Type: Added, Element: ElementNode, Node: newNode.UUID, UUID: newNode.UUID, New: summarizeNode(&newNode)})
+		}
+	}
+}
+
+func (c *comparer) compareNode(old, new *definitions.Node) {
+	node := new.UUID
+
+	oldActions := make(map[string]*definitions.Action, len(old.Actions))
+	for i := range old.Actions {
+		oldActions[old.Actions[i].UUID] = &old.Actions[i]
+	}
+	for i := range old.Actions {
+		action := &old.Actions[i]
+		if findAction(new, action.UUID) == nil {
+			c.add(Change{Type: Removed, Element: ElementAction, Node: node, UUID: action.UUID, Old: marshal(action)})
+		}
+	}
+	for i := range new.Actions {
+		action := &new.Actions[i]
+		if oldAction, ok := oldActions[action.UUID]; ok {
+			c.compareFields(ElementAction, node, action.UUID, oldAction, action)
+		} else {
+			c.add(Change{Type: Added, Element: ElementAction, Node: node, UUID: action.UUID, New: marshal(action)})
+		}
+	}
+
+	c.compareRouter(node, old.Router, new.Router)
+
+	for _, oldExit := range old.Exits {
+		newExit := new.Exit(oldExit.UUID)
+		if newExit == nil {
+			c.add(Change{Type: Removed, Element: ElementExit, Node: node, UUID: oldExit.UUID, Old: destination(oldExit.DestinationUUID)})
+		} else if newExit.DestinationUUID != oldExit.DestinationUUID {
+			c.add(Change{Type: Changed, Element: ElementExit, Node: node, UUID: oldExit.UUID, Field: "destination_uuid",
+				Old: destination(oldExit.DestinationUUID), New: destination(newExit.DestinationUUID)})
+		}
+	}
+	for _, newExit := range new.Exits {
+		if old.Exit(newExit.UUID) == nil {
+			c.add(Change{Type: Added, Element: ElementExit, Node: node, UUID: newExit.UUID, New: destination(newExit.DestinationUUID)})
+		}
+	}
+}
+
+func (c *comparer) compareRouter(node string, old, new *definitions.Router) {
+	switch {
+	case old == nil && new == nil:
+		return
+	case old == nil:
+		c.add(Change{Type: Added, Element: ElementRouter, Node: node, New: summarizeRouter(new)})
+		return
+	case new == nil:
+		c.add(Change{Type: Removed, Element: ElementRouter, Node: node, Old: summarizeRouter(old)})
+		return
+	}
+
+	props := func(r *definitions.Router) interface{} {
+		return &definitions.Router{
+			Type:                r.Type,
+			Operand:             r.Operand,
+			DefaultCategoryUUID: r.DefaultCategoryUUID,
+			ResultName:          r.ResultName,
+			Wait:                r.Wait,
+		}
+	}
+	c.compareFields(ElementRouter, node, "", props(old), props(new))
+
+	for _, oldCase := range old.Cases {
+		newCase := findCase(new, oldCase.UUID)
+		if newCase == nil {
+			c.add(Change{Type: Removed, Element: ElementCase, Node: node, UUID: oldCase.UUID, Old: summarizeCase(old, &oldCase)})
+		} else if oldSummary, newSummary := summarizeCase(old, &oldCase), summarizeCase(new, newCase); oldSummary != newSummary {
+			c.add(Change{Type: Changed, Element: ElementCase, Node: node, UUID: oldCase.UUID, Old: oldSummary, New: newSummary})
+		}
+	}
+	for _, newCase := range new.Cases {
+		if findCase(old, newCase.UUID) == nil {
+			c.add(Change{Type: Added, Element: ElementCase, Node: node, UUID: newCase.UUID, New: summarizeCase(new, &newCase)})
+		}
+	}
+
+	for _, oldCategory := range old.Categories {
+		newCategory := new.Category(oldCategory.UUID)
+		if newCategory == nil {
+			c.add(Change{Type: Removed, Element: ElementCategory, Node: node, UUID: oldCategory.UUID, Old: oldCategory.Name})
+		} else {
+			c.compareFields(ElementCategory, node, oldCategory.UUID, &oldCategory, newCategory)
+		}
+	}
+	for _, newCategory := range new.Categories {
+		if old.Category(newCategory.UUID) == nil {
+			c.add(Change{Type: Added, Element: ElementCategory, Node: node, UUID: newCategory.UUID, New: newCategory.Name})
+		}
+	}
+}
+
+func (c *comparer) compareTranslations(old, new definitions.Localization) {
+	for _, lang := range languages(old, new) {
+		for _, uuid := range translatedItems(old[lang], new[lang]) {
+			for _, key := range translatedProperties(old[lang][uuid], new[lang][uuid]) {
+				oldValue, inOld := old[lang][uuid][key]
+				newValue, inNew := new[lang][uuid][key]
+				change := Change{Element: ElementTranslation, UUID: uuid, Language: lang, Field: key, Old: marshal(oldValue), New: marshal(newValue)}
+				switch {
+				case !inOld:
+					change.Type, change.Old = Added, ""
+				case !inNew:
+					change.Type, change.New = Removed, ""
+				case change.Old != change.New:
+					change.Type = Changed
+				default:
+					continue
+				}
+				c.add(change)
+			}
+		}
+	}
+}
+
+func (c *comparer) compareResults(old, new *definitions.Flow) {
+	oldResults, newResults := results(old), results(new)
+	for _, key := range stringKeys(oldResults, newResults) {
+		oldName, inOld := oldResults[key]
+		newName, inNew := newResults[key]
+		switch {
+		case !inOld:
+			c.add(Change{Type: Added, Element: ElementResult, UUID: key, New: newName})
+		case !inNew:
+			c.add(Change{Type: Removed, Element: ElementResult, UUID: key, Old: oldName})
+		case oldName != newName:
+			c.add(Change{Type: Changed, Element: ElementResult, UUID: key, Field: "name", Old: oldName, New: newName})
+		}
+	}
+}
+
+// results returns the result names of a flow by their key
+func results(flow *definitions.Flow) map[string]string {
+	names := make(map[string]string)
+	for _, node := range flow.Nodes {
+		for _, action := range node.Actions {
+			if action.Type == definitions.ActionTypeSetRunResult && action.Name != "" {
+				names[definitions.ResultKey(action.Name)] = action.Name
+			}
+		}
+		if node.Router != nil && node.Router.ResultName != "" {
+			names[definitions.ResultKey(node.Router.ResultName)] = node.Router.ResultName
+		}
+	}
+	return names
+}
+
+func findAction(node *definitions.Node, uuid string) *definitions.Action {
+	for i := range node.Actions {
+		if node.Actions[i].UUID == uuid {
+			return &node.Actions[i]
+		}
+	}
+	return nil
+}
+
+func findCase(router *definitions.Router, uuid string) *definitions.Case {
+	for i := range router.Cases {
+		if router.Cases[i].UUID == uuid {
+			return &router.Cases[i]
+		}
+	}
+	return nil
+}
+
+func summarizeNode(node *definitions.Node) string {
+	parts := make([]string, 0, len(node.Actions)+1)
+	for _, action := range node.Actions {
+		parts = append(parts, action.Type)
+	}
+	if node.Router != nil {
+		parts = append(parts, summarizeRouter(node.Router))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func summarizeRouter(router *definitions.Router) string {
+	summary := router.Type + " router"
+	if router.Operand != "" {
+		summary += " on " + router.Operand
+	}
+	return summary
+}
+
+// summarizeCase describes a case test along with the name of the category it leads to
+func summarizeCase(router *definitions.Router, c *definitions.Case) string {
+	summary := c.Type + "(" + strings.Join(c.Arguments, ", ") + ")"
+	if category := router.Category(c.CategoryUUID); category != nil {
+		summary += " → " + category.Name
+	}
+	return summary
+}
+
+func destination(uuid string) string {
+	if uuid == "" {
+		return "(end)"
+	}
+	return uuid
+}
+
+// fields returns the top level JSON fields of a value rendered as text
+func fields(v interface{}) map[string]string {
+	raw := make(map[string]json.RawMessage)
+	b, _ := json.Marshal(v)
+	_ = json.Unmarshal(b, &raw)
+
+	result := make(map[string]string, len(raw))
+	for key, value := range raw {
+		var s string
+		if json.Unmarshal(value, &s) == nil {
+			result[key] = s
+		} else {
+			result[key] = string(value)
+		}
+	}
+	return result
+}
+
+func marshal(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// languages returns the union of the languages of two localizations sorted
+func languages(a, b definitions.Localization) []string {
+	seen := make(map[string]bool)
+	for _, m := range []definitions.Localization{a, b} {
+		for key := range m {
+			seen[key] = true
+		}
+	}
+	return sortedSet(seen)
+}
+
+// translatedItems returns the union of the uuids of the translated items of two languages sorted
+func translatedItems(a, b map[string]map[string][]string) []string {
+	seen := make(map[string]bool)
+	for _, m := range []map[string]map[string][]string{a, b} {
+		for key := range m {
+			seen[key] = true
+		}
+	}
+	return sortedSet(seen)
+}
+
+// translatedProperties returns the union of the translated properties of two items sorted
+func translatedProperties(a, b map[string][]string) []string {
+	seen := make(map[string]bool)
+	for _, m := range []map[string][]string{a, b} {
+		for key := range m {
+			seen[key] = true
+		}
+	}
+	return sortedSet(seen)
+}
+
+// stringKeys returns the union of the keys of two string maps sorted
+func stringKeys(a, b map[string]string) []string {
+	seen := make(map[string]bool)
+	for _, m := range []map[string]string{a, b} {
+		for key := range m {
+			seen[key] = true
+		}
+	}
+	return sortedSet(seen)
+}
+
+func sortedSet(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,183 @@
+package diff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rasoro/rapidpro-api-go/v2/definitions"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeExport(t *testing.T, data string) *definitions.Export {
+	export := &definitions.Export{}
+	assert.NoError(t, json.Unmarshal([]byte(data), export))
+	return export
+}
+
+func TestCompare(t *testing.T) {
+	old, new := decodeExport(t, testOld), decodeExport(t, testNew)
+	d := Compare(&old.Flows[0], &new.Flows[0])
+
+	assert.Equal(t, "flow-1", d.Flow)
+	assert.Equal(t, 3, d.OldRevision)
+	assert.Equal(t, 4, d.NewRevision)
+	assert.Equal(t, []Change{
+		{Type: Changed, Element: ElementFlow, Field: "name", Old: "Registration", New: "Sign Up"},
+		{Type: Changed, Element: ElementAction, Node: "node-ask", UUID: "action-ask", Field: "text", Old: "What is your favorite color?", New: "What's your favorite color?"},
+		{Type: Changed, Element: ElementRouter, Node: "node-ask", Field: "result_name", Old: "Color", New: "Favorite Color"},
+		{Type: Changed, Element: ElementCase, Node: "node-ask", UUID: "case-red", Old: "has_any_word(red) → Red", New: "has_any_word(red, rouge) → Red"},
+		{Type: Removed, Element: ElementCase, Node: "node-ask", UUID: "case-blue", Old: "has_any_word(blue) → Blue"},
+		{Type: Removed, Element: ElementCategory, Node: "node-ask", UUID: "category-blue", Old: "Blue"},
+		{Type: Removed, Element: ElementExit, Node: "node-ask", UUID: "exit-blue", Old: "node-thanks"},
+		{Type: Removed, Element: ElementAction, Node: "node-thanks", UUID: "action-thanks", Old: `{"uuid":"action-thanks","type":"send_msg","text":"Thanks!"}`},
+		{Type: Added, Element: ElementAction, Node: "node-thanks", UUID: "action-bye", New: `{"uuid":"action-bye","type":"send_msg","text":"Bye!"}`},
+		{Type: Changed, Element: ElementExit, Node: "node-thanks", UUID: "exit-thanks", Field: "destination_uuid", Old: "(end)", New: "node-tag"},
+		{Type: Added, Element: ElementNode, Node: "node-tag", UUID: "node-tag", New: "set_run_result"},
+		{Type: Added, Element: ElementTranslation, UUID: "action-ask", Language: "fra", Field: "quick_replies", New: `["Rouge"]`},
+		{Type: Changed, Element: ElementTranslation, UUID: "action-ask", Language: "fra", Field: "text", Old: `["Quelle est ta couleur préférée?"]`, New: `["Ta couleur préférée?"]`},
+		{Type: Removed, Element: ElementResult, UUID: "color", Old: "Color"},
+		{Type: Added, Element: ElementResult, UUID: "favorite_color", New: "Favorite Color"},
+		{Type: Added, Element: ElementResult, UUID: "tagged", New: "Tagged"},
+	}, d.Changes)
+
+	assert.False(t, d.Empty())
+	assert.True(t, Compare(&old.Flows[0], &old.Flows[0]).Empty())
+}
+
+func TestDiffRendering(t *testing.T) {
+	d := &Diff{
+		Flow:        "flow-1",
+		Name:        "Sign Up",
+		OldRevision: 3,
+		NewRevision: 4,
+		Changes: []Change{
+			{Type: Changed, Element: ElementFlow, Field: "name", Old: "Registration", New: "Sign Up"},
+			{Type: Removed, Element: ElementCategory, Node: "node-ask", UUID: "category-blue", Old: "Blue"},
+			{Type: Added, Element: ElementTranslation, UUID: "action-ask", Language: "fra", Field: "quick_replies", New: `["Rouge"]`},
+		},
+	}
+
+	assert.Equal(t, `Flow Sign Up (flow-1) revision 3 → 4
+  ~ flow name: Registration → Sign Up
+  - category category-blue in node node-ask: Blue
+  + translation fra action-ask quick_replies: ["Rouge"]
+`, d.String())
+
+	b, err := json.Marshal(d)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"flow": "flow-1",
+		"name": "Sign Up",
+		"old_revision": 3,
+		"new_revision": 4,
+		"changes": [
+			{"type": "changed", "element": "flow", "field": "name", "old": "Registration", "new": "Sign Up"},
+			{"type": "removed", "element": "category", "node": "node-ask", "uuid": "category-blue", "old": "Blue"},
+			{"type": "added", "element": "translation", "uuid": "action-ask", "language": "fra", "field": "quick_replies", "new": "[\"Rouge\"]"}
+		]
+	}`, string(b))
+}
+
+func TestCompareExports(t *testing.T) {
+	old, new := decodeExport(t, testOld), decodeExport(t, testNew)
+	old.Flows = append(old.Flows, definitions.Flow{UUID: "flow-2", Name: "Deleted", Revision: 1})
+	new.Flows = append(new.Flows, definitions.Flow{UUID: "flow-3", Name: "Created", Revision: 1})
+
+	diffs := CompareExports(old, new)
+	assert.Equal(t, 3, len(diffs))
+	assert.Equal(t, "flow-1", diffs[0].Flow)
+	assert.Equal(t, []Change{{Type: Removed, Element: ElementFlow, UUID: "flow-2", Old: "Deleted"}}, diffs[1].Changes)
+	assert.Equal(t, []Change{{Type: Added, Element: ElementFlow, UUID: "flow-3", New: "Created"}}, diffs[2].Changes)
+}
+
+var testOld = `{
+	"flows": [
+		{
+			"uuid": "flow-1",
+			"name": "Registration",
+			"language": "eng",
+			"type": "messaging",
+			"revision": 3,
+			"localization": {
+				"fra": {"action-ask": {"text": ["Quelle est ta couleur préférée?"]}}
+			},
+			"nodes": [
+				{
+					"uuid": "node-ask",
+					"actions": [{"uuid": "action-ask", "type": "send_msg", "text": "What is your favorite color?"}],
+					"router": {
+						"type": "switch",
+						"operand": "@input.text",
+						"result_name": "Color",
+						"wait": {"type": "msg"},
+						"cases": [
+							{"uuid": "case-red", "type": "has_any_word", "arguments": ["red"], "category_uuid": "category-red"},
+							{"uuid": "case-blue", "type": "has_any_word", "arguments": ["blue"], "category_uuid": "category-blue"}
+						],
+						"categories": [
+							{"uuid": "category-red", "name": "Red", "exit_uuid": "exit-red"},
+							{"uuid": "category-blue", "name": "Blue", "exit_uuid": "exit-blue"}
+						],
+						"default_category_uuid": "category-red"
+					},
+					"exits": [
+						{"uuid": "exit-red", "destination_uuid": "node-thanks"},
+						{"uuid": "exit-blue", "destination_uuid": "node-thanks"}
+					]
+				},
+				{
+					"uuid": "node-thanks",
+					"actions": [{"uuid": "action-thanks", "type": "send_msg", "text": "Thanks!"}],
+					"exits": [{"uuid": "exit-thanks"}]
+				}
+			]
+		}
+	]
+}`
+
+var testNew = `{
+	"flows": [
+		{
+			"uuid": "flow-1",
+			"name": "Sign Up",
+			"language": "eng",
+			"type": "messaging",
+			"revision": 4,
+			"localization": {
+				"fra": {"action-ask": {"text": ["Ta couleur préférée?"], "quick_replies": ["Rouge"]}}
+			},
+			"nodes": [
+				{
+					"uuid": "node-ask",
+					"actions": [{"uuid": "action-ask", "type": "send_msg", "text": "What's your favorite color?"}],
+					"router": {
+						"type": "switch",
+						"operand": "@input.text",
+						"result_name": "Favorite Color",
+						"wait": {"type": "msg"},
+						"cases": [
+							{"uuid": "case-red", "type": "has_any_word", "arguments": ["red", "rouge"], "category_uuid": "category-red"}
+						],
+						"categories": [
+							{"uuid": "category-red", "name": "Red", "exit_uuid": "exit-red"}
+						],
+						"default_category_uuid": "category-red"
+					},
+					"exits": [
+						{"uuid": "exit-red", "destination_uuid": "node-thanks"}
+					]
+				},
+				{
+					"uuid": "node-thanks",
+					"actions": [{"uuid": "action-bye", "type": "send_msg", "text": "Bye!"}],
+					"exits": [{"uuid": "exit-thanks", "destination_uuid": "node-tag"}]
+				},
+				{
+					"uuid": "node-tag",
+					"actions": [{"uuid": "action-tag", "type": "set_run_result", "name": "Tagged", "value": "yes"}],
+					"exits": [{"uuid": "exit-tag"}]
+				}
+			]
+		}
+	]
+}`
@@ -0,0 +1,187 @@
+package export
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Ways nested objects are flattened
+const (
+	// ObjectsColumns splits nested objects into a column per field, e.g. contact_uuid and contact_name
+	ObjectsColumns = "columns"
+	// ObjectsJSON writes nested objects as JSON in a single column
+	ObjectsJSON = "json"
+)
+
+// Ways lists are flattened
+const (
+	// ListsNames joins the names of list items, or the items themselves if they're strings, with the separator
+	ListsNames = "names"
+	// ListsJSON writes lists as JSON in a single column
+	ListsJSON = "json"
+)
+
+// DefaultSeparator joins list items when Flattening.Separator isn't set
+const DefaultSeparator = "|"
+
+// Flattening configures how nested records are turned into columns
+type Flattening struct {
+	Objects   string
+	Lists     string
+	Separator string
+}
+
+// kinds of column values
+const (
+	kindString = iota
+	kindInt
+	kindFloat
+	kindBool
+	kindTime
+	kindJSON
+	kindNames
+)
+
+type column struct {
+	name  string
+	kind  int
+	index []int
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+// columnsOf returns the columns of a record type, in the order of its fields
+func columnsOf(t reflect.Type, flattening *Flattening) []column {
+	return appendColumns(nil, t, "", nil, flattening)
+}
+
+func appendColumns(columns []column, t reflect.Type, prefix string, index []int, flattening *Flattening) []column {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := jsonName(field)
+		if field.PkgPath != "" || name == "-" {
+			continue
+		}
+		c := column{name: prefix + name, index: append(append([]int{}, index...), i)}
+
+		ft := field.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		switch {
+		case ft == timeType:
+			c.kind = kindTime
+		case ft == rawMessageType || ft.Kind() == reflect.Map || ft.Kind() == reflect.Interface:
+			c.kind = kindJSON
+		case ft.Kind() == reflect.Struct:
+			if flattening.Objects == ObjectsColumns {
+				columns = appendColumns(columns, ft, c.name+"_", c.index, flattening)
+				continue
+			}
+			c.kind = kindJSON
+		case ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array:
+			c.kind = kindJSON
+			if flattening.Lists == ListsNames && hasNames(ft.Elem()) {
+				c.kind = kindNames
+			}
+		case ft.Kind() == reflect.Bool:
+			c.kind = kindBool
+		case ft.Kind() >= reflect.Int && ft.Kind() <= reflect.Uint64:
+			c.kind = kindInt
+		case ft.Kind() == reflect.Float32 || ft.Kind() == reflect.Float64:
+			c.kind = kindFloat
+		default:
+			c.kind = kindString
+		}
+		columns = append(columns, c)
+	}
+	return columns
+}
+
+// jsonName returns the name of a field in JSON
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+// hasNames returns whether list items of a type can be written as names
+func hasNames(t reflect.Type) bool {
+	if t.Kind() == reflect.String {
+		return true
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	_, ok := t.FieldByName("Name")
+	return ok
+}
+
+// value returns the value of a column of a record, which is nil when it's not set
+func (c *column) value(record reflect.Value, separator string) interface{} {
+	v := record
+	for _, i := range c.index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch c.kind {
+	case kindTime:
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return nil
+		}
+		return t.UTC()
+	case kindJSON:
+		if raw, ok := v.Interface().(json.RawMessage); ok {
+			if len(raw) == 0 {
+				return nil
+			}
+			return raw
+		}
+		if (v.Kind() == reflect.Map || v.Kind() == reflect.Interface) && v.IsNil() {
+			return nil
+		}
+		b, _ := json.Marshal(v.Interface())
+		return json.RawMessage(b)
+	case kindNames:
+		names := make([]string, v.Len())
+		for i := range names {
+			item := v.Index(i)
+			if item.Kind() == reflect.Struct {
+				item = item.FieldByName("Name")
+			}
+			names[i] = item.String()
+		}
+		return strings.Join(names, separator)
+	case kindInt:
+		if v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uint64 {
+			return int64(v.Uint())
+		}
+		return v.Int()
+	case kindFloat:
+		return v.Float()
+	case kindBool:
+		return v.Bool()
+	}
+	return v.String()
+}
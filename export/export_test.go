@@ -0,0 +1,299 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/rasoro/rapidpro-api-go/v2/flows"
+	"github.com/rasoro/rapidpro-api-go/v2/flowstarts"
+	"github.com/rasoro/rapidpro-api-go/v2/messages"
+	"github.com/stretchr/testify/assert"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+type memFile struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (f *memFile) Close() error {
+	f.closed = true
+	return nil
+}
+
+type memFiles []*memFile
+
+func (m *memFiles) open(index int) (io.WriteCloser, error) {
+	f := &memFile{}
+	*m = append(*m, f)
+	return f, nil
+}
+
+func decodeMessages(t *testing.T) []messages.Message {
+	resp := &messages.Response{}
+	assert.NoError(t, json.Unmarshal([]byte(testMessages), resp))
+	return resp.Results
+}
+
+func TestCSV(t *testing.T) {
+	files := &memFiles{}
+	w, err := NewWriter(messages.Message{}, &Options{Format: FormatCSV, Open: files.open})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id", "broadcast", "contact_uuid", "contact_name", "urn", "channel_uuid", "channel_name",
+		"direction", "type", "status", "visibility", "text", "attachments", "labels", "created_on", "sent_on", "modified_on"}, w.Columns())
+
+	for _, msg := range decodeMessages(t) {
+		assert.NoError(t, w.Write(msg))
+	}
+	assert.NoError(t, w.Close())
+	assert.Equal(t, 1, w.Files())
+	assert.Equal(t, 2, w.Rows())
+	assert.True(t, (*files)[0].closed)
+
+	lines := strings.Split((*files)[0].String(), "\n")
+	assert.Equal(t, "id,broadcast,contact_uuid,contact_name,urn,channel_uuid,channel_name,direction,type,status,visibility,text,attachments,labels,created_on,sent_on,modified_on", lines[0])
+	assert.Equal(t, `4105426,2690007,d33e9ad5-5c35-414c-abd4-e7451c69ff1d,Bob McFlow,tel:+250788123123,9a8b001e-a913-486c-80f4-1356e23f582e,Vonage,out,inbox,wired,visible,"How are you, Bob?","[{""content_type"":""audio/wav"",""url"":""http://domain.com/recording.wav""}]","[{""name"":""Important"",""uuid"":""5a4eb79e-1b1f-4ae3-8700-09384cca385f""},{""name"":""Follow Up"",""uuid"":""e74c6be1-dcfb-42ba-96d5-692d3d19b63d""}]",2016-01-06T15:33:00.813162Z,2016-01-06T15:35:03.675716Z,2016-01-06T15:35:03.675716Z`, lines[1])
+	assert.Equal(t, `5216537,0,d33e9ad5-5c35-414c-abd4-e7451c69ff1d,Bob McFlow,tel:+250788123123,,,in,inbox,handled,visible,Fine,[],[],2016-01-07T10:00:00Z,,2016-01-07T10:00:00Z`, lines[2])
+}
+
+func TestInvalidWrites(t *testing.T) {
+	_, err := NewWriter(messages.Message{}, nil)
+	assert.EqualError(t, err, "export options must have an Open function")
+
+	files := &memFiles{}
+	w, err := NewWriter(messages.Message{}, &Options{Format: FormatCSV, Open: files.open})
+	assert.NoError(t, err)
+	assert.EqualError(t, w.Write(nil), "can't write <nil> to a writer of Message records")
+	assert.EqualError(t, w.Write((*messages.Message)(nil)), "can't write *messages.Message to a writer of Message records")
+	assert.EqualError(t, w.Write(flows.Flow{}), "can't write flows.Flow to a writer of Message records")
+	assert.Equal(t, 0, w.Rows())
+	assert.Len(t, *files, 0)
+}
+
+func TestCSVColumnsAndFlattening(t *testing.T) {
+	files := &memFiles{}
+	w, err := NewWriter(&messages.Message{}, &Options{
+		Format:     FormatCSV,
+		Columns:    []string{"id", "contact", "labels"},
+		Flattening: &Flattening{Objects: ObjectsJSON, Lists: ListsNames, Separator: ";"},
+		Open:       files.open,
+	})
+	assert.NoError(t, err)
+
+	msgs := decodeMessages(t)
+	assert.NoError(t, w.Write(&msgs[0]))
+	assert.NoError(t, w.Close())
+	assert.Equal(t, `id,contact,labels
+4105426,"{""uuid"":""d33e9ad5-5c35-414c-abd4-e7451c69ff1d"",""name"":""Bob McFlow""}",Important;Follow Up
+`, (*files)[0].String())
+
+	_, err = NewWriter(messages.Message{}, &Options{Format: FormatCSV, Columns: []string{"contact"}, Open: files.open})
+	assert.EqualError(t, err, "Message records have no column contact")
+
+	_, err = NewWriter(messages.Message{}, &Options{Format: "xml", Open: files.open})
+	assert.EqualError(t, err, `unknown export format "xml"`)
+
+	_, err = NewWriter("text", &Options{Format: FormatCSV, Open: files.open})
+	assert.EqualError(t, err, "can't export records of type string")
+
+	assert.EqualError(t, w.Write(flows.Flow{}), "can't write flows.Flow to a writer of Message records")
+}
+
+func TestNDJSON(t *testing.T) {
+	files := &memFiles{}
+	w, err := NewWriter(flows.Flow{}, &Options{
+		Format:     FormatNDJSON,
+		Columns:    []string{"uuid", "name", "archived", "expires", "runs_completed", "labels", "created_on"},
+		Flattening: &Flattening{Objects: ObjectsColumns, Lists: ListsJSON},
+		Open:       files.open,
+	})
+	assert.NoError(t, err)
+
+	flow := &flows.Flow{}
+	assert.NoError(t, json.Unmarshal([]byte(testFlow), flow))
+	assert.NoError(t, w.Write(flow))
+	assert.NoError(t, w.Close())
+
+	assert.Equal(t, `{"uuid":"5f05311e-8f81-4a67-a5b5-1501b6d6496a","name":"Survey","archived":false,"expires":600,"runs_completed":123,"labels":[{"uuid":"5a4eb79e-1b1f-4ae3-8700-09384cca385f","name":"Important"}],"created_on":"2016-01-06T15:33:00.813162Z"}
+`, (*files)[0].String())
+}
+
+func TestParquet(t *testing.T) {
+	files := &memFiles{}
+	w, err := NewWriter(flowstarts.FlowStart{}, &Options{
+		Format:  FormatParquet,
+		Columns: []string{"uuid", "flow_name", "status", "restart_participants", "params", "created_on", "modified_on"},
+		Open:    files.open,
+	})
+	assert.NoError(t, err)
+
+	start := &flowstarts.FlowStart{}
+	assert.NoError(t, json.Unmarshal([]byte(testFlowStart), start))
+	assert.NoError(t, w.Write(start))
+	assert.NoError(t, w.Close())
+
+	bf, err := buffer.NewBufferFile((*files)[0].Bytes())
+	assert.NoError(t, err)
+	pr, err := reader.NewParquetReader(bf, nil, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), pr.GetNumRows())
+
+	rows, err := pr.ReadByNumber(1)
+	assert.NoError(t, err)
+	row, _ := json.Marshal(rows[0])
+	assert.JSONEq(t, `{
+		"Uuid": "09d23a05-47fe-11e4-bfe9-b8f6b119e9ab",
+		"Flow_name": "Registration",
+		"Status": "complete",
+		"Restart_participants": true,
+		"Params": "{\"first_name\": \"Ryan\"}",
+		"Created_on": 1451928180123456,
+		"Modified_on": null
+	}`, string(row))
+	pr.ReadStop()
+}
+
+func TestRotation(t *testing.T) {
+	files := &memFiles{}
+	w, err := NewWriter(messages.Message{}, &Options{Format: FormatCSV, Columns: []string{"id", "text"}, MaxBytes: 40, Open: files.open})
+	assert.NoError(t, err)
+
+	msgs := decodeMessages(t)
+	for i := 0; i < 3; i++ {
+		for _, msg := range msgs {
+			assert.NoError(t, w.Write(msg))
+		}
+	}
+	assert.NoError(t, w.Close())
+
+	assert.Equal(t, 3, w.Files())
+	for _, f := range *files {
+		assert.True(t, f.closed)
+		assert.Equal(t, "id,text\n4105426,\"How are you, Bob?\"\n5216537,Fine\n", f.String())
+	}
+}
+
+func TestFileOpener(t *testing.T) {
+	dir, err := ioutil.TempDir("", "export")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	w, err := NewWriter(messages.Message{}, &Options{
+		Format:   FormatNDJSON,
+		Columns:  []string{"id"},
+		MaxBytes: 1,
+		Open:     FileOpener(filepath.Join(dir, "messages", "part-%03d.ndjson")),
+	})
+	assert.NoError(t, err)
+	for _, msg := range decodeMessages(t) {
+		assert.NoError(t, w.Write(msg))
+	}
+	assert.NoError(t, w.Close())
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "messages", "part-001.ndjson"))
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"id\":5216537}\n", string(data))
+}
+
+func TestCopy(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "in", r.URL.Query().Get("folder"))
+			if r.URL.Query().Get("cursor") == "" {
+				w.Write([]byte(`{"next": "https://rapidpro.io/api/v2/messages.json?cursor=cD0y", "results": [{"id": 1}, {"id": 2}]}`))
+				return
+			}
+			assert.Equal(t, "cD0y", r.URL.Query().Get("cursor"))
+			w.Write([]byte(`{"next": null, "results": [{"id": 3}]}`))
+		}))
+	defer mockServer.Close()
+
+	service := messages.NewService(client.NewRequestHandler(&client.Client{Credentials: &client.Credentials{Token: "token123"}}), mockServer.URL)
+	files := &memFiles{}
+	w, err := NewWriter(messages.Message{}, &Options{Format: FormatCSV, Columns: []string{"id"}, Open: files.open})
+	assert.NoError(t, err)
+
+	n, err := Copy(context.Background(), MessagesPager(service, &messages.QueryParams{Folder: "in"}), w)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.NoError(t, w.Close())
+	assert.Equal(t, "id\n1\n2\n3\n", (*files)[0].String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Copy(ctx, MessagesPager(service, nil), w)
+	assert.Equal(t, context.Canceled, err)
+}
+
+var testMessages = `{
+	"next": null,
+	"previous": null,
+	"results": [
+		{
+			"id": 4105426,
+			"broadcast": 2690007,
+			"contact": {"uuid": "d33e9ad5-5c35-414c-abd4-e7451c69ff1d", "name": "Bob McFlow"},
+			"urn": "tel:+250788123123",
+			"channel": {"uuid": "9a8b001e-a913-486c-80f4-1356e23f582e", "name": "Vonage"},
+			"direction": "out",
+			"type": "inbox",
+			"status": "wired",
+			"visibility": "visible",
+			"text": "How are you, Bob?",
+			"attachments": [{"content_type": "audio/wav", "url": "http://domain.com/recording.wav"}],
+			"labels": [{"name": "Important", "uuid": "5a4eb79e-1b1f-4ae3-8700-09384cca385f"}, {"name": "Follow Up", "uuid": "e74c6be1-dcfb-42ba-96d5-692d3d19b63d"}],
+			"created_on": "2016-01-06T15:33:00.813162Z",
+			"sent_on": "2016-01-06T15:35:03.675716Z",
+			"modified_on": "2016-01-06T15:35:03.675716Z"
+		},
+		{
+			"id": 5216537,
+			"contact": {"uuid": "d33e9ad5-5c35-414c-abd4-e7451c69ff1d", "name": "Bob McFlow"},
+			"urn": "tel:+250788123123",
+			"direction": "in",
+			"type": "inbox",
+			"status": "handled",
+			"visibility": "visible",
+			"text": "Fine",
+			"attachments": [],
+			"labels": [],
+			"created_on": "2016-01-07T10:00:00Z",
+			"sent_on": null,
+			"modified_on": "2016-01-07T10:00:00Z"
+		}
+	]
+}`
+
+var testFlow = `{
+	"uuid": "5f05311e-8f81-4a67-a5b5-1501b6d6496a",
+	"name": "Survey",
+	"type": "message",
+	"archived": false,
+	"labels": [{"name": "Important", "uuid": "5a4eb79e-1b1f-4ae3-8700-09384cca385f"}],
+	"expires": 600,
+	"runs": {"active": 47, "completed": 123, "interrupted": 2, "expired": 34},
+	"results": [],
+	"parent_refs": [],
+	"created_on": "2016-01-06T15:33:00.813162Z",
+	"modified_on": "2017-01-07T13:14:00.453567Z"
+}`
+
+var testFlowStart = `{
+	"uuid": "09d23a05-47fe-11e4-bfe9-b8f6b119e9ab",
+	"flow": {"uuid": "f5901b62-ba76-4003-9c62-72fdacc1b7b7", "name": "Registration"},
+	"status": "complete",
+	"restart_participants": true,
+	"params": {"first_name": "Ryan"},
+	"created_on": "2016-01-04T17:23:00.123456Z"
+}`
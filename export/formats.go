@@ -0,0 +1,171 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+type csvEncoder struct {
+	file *countingWriter
+	csv  *csv.Writer
+}
+
+func newCSVEncoder(file *countingWriter, columns []column) (encoder, error) {
+	e := &csvEncoder{file: file, csv: csv.NewWriter(file)}
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.name
+	}
+	return e, e.csv.Write(header)
+}
+
+func (e *csvEncoder) write(row []interface{}) error {
+	record := make([]string, len(row))
+	for i, value := range row {
+		record[i] = formatText(value)
+	}
+	return e.csv.Write(record)
+}
+
+func (e *csvEncoder) size() int64 {
+	e.csv.Flush()
+	return e.file.count
+}
+
+func (e *csvEncoder) close() error {
+	e.csv.Flush()
+	return e.csv.Error()
+}
+
+// formatText formats a value as text
+func formatText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case json.RawMessage:
+		return string(v)
+	}
+	return ""
+}
+
+type ndjsonEncoder struct {
+	file    *countingWriter
+	columns []column
+}
+
+func newNDJSONEncoder(file *countingWriter, columns []column) encoder {
+	return &ndjsonEncoder{file: file, columns: columns}
+}
+
+// write writes a row as a JSON object with its keys in the order of the columns
+func (e *ndjsonEncoder) write(row []interface{}) error {
+	b := &strings.Builder{}
+	b.WriteByte('{')
+	for i, value := range row {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(e.columns[i].name)
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(encoded)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(e.file, b.String())
+	return err
+}
+
+func (e *ndjsonEncoder) size() int64 {
+	return e.file.count
+}
+
+func (e *ndjsonEncoder) close() error {
+	return nil
+}
+
+// parquetTypes are the parquet types of each kind of column
+var parquetTypes = map[int]string{
+	kindString: "type=BYTE_ARRAY, convertedtype=UTF8",
+	kindInt:    "type=INT64",
+	kindFloat:  "type=DOUBLE",
+	kindBool:   "type=BOOLEAN",
+	kindTime:   "type=INT64, convertedtype=TIMESTAMP_MICROS",
+	kindJSON:   "type=BYTE_ARRAY, convertedtype=UTF8",
+	kindNames:  "type=BYTE_ARRAY, convertedtype=UTF8",
+}
+
+type parquetEncoder struct {
+	file    *countingWriter
+	columns []column
+	writer  *writer.JSONWriter
+}
+
+func newParquetEncoder(file *countingWriter, columns []column) (encoder, error) {
+	type field struct {
+		Tag string `json:"Tag"`
+	}
+	schema := struct {
+		Tag    string  `json:"Tag"`
+		Fields []field `json:"Fields"`
+	}{Tag: "name=record, repetitiontype=REQUIRED"}
+	for _, c := range columns {
+		schema.Fields = append(schema.Fields, field{Tag: "name=" + c.name + ", " + parquetTypes[c.kind] + ", repetitiontype=OPTIONAL"})
+	}
+	schemaJSON, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := writer.NewJSONWriterFromWriter(string(schemaJSON), file, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &parquetEncoder{file: file, columns: columns, writer: w}, nil
+}
+
+func (e *parquetEncoder) write(row []interface{}) error {
+	values := make(map[string]interface{}, len(row))
+	for i, value := range row {
+		switch v := value.(type) {
+		case time.Time:
+			value = v.UnixNano() / int64(time.Microsecond)
+		case json.RawMessage:
+			value = string(v)
+		}
+		values[e.columns[i].name] = value
+	}
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return e.writer.Write(string(encoded))
+}
+
+// size returns the bytes written plus an estimate of the rows still buffered by the parquet writer
+func (e *parquetEncoder) size() int64 {
+	return e.file.count + e.writer.ObjsSize + e.writer.Size
+}
+
+func (e *parquetEncoder) close() error {
+	return e.writer.WriteStop()
+}
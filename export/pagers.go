@@ -0,0 +1,96 @@
+package export
+
+import (
+	"context"
+
+	"github.com/rasoro/rapidpro-api-go/v2/flows"
+	"github.com/rasoro/rapidpro-api-go/v2/flowstarts"
+	"github.com/rasoro/rapidpro-api-go/v2/messages"
+)
+
+// Pager fetches the page of records at a cursor, returning the cursor of the next page or an empty string on the last page
+type Pager func(cursor string) (records []interface{}, next string, err error)
+
+// Copy writes the records of every page to a Writer and returns the number of records written
+func Copy(ctx context.Context, pager Pager, w *Writer) (int, error) {
+	written, cursor := 0, ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+		records, next, err := pager(cursor)
+		if err != nil {
+			return written, err
+		}
+		for _, record := range records {
+			if err := w.Write(record); err != nil {
+				return written, err
+			}
+			written++
+		}
+		if next == "" {
+			return written, nil
+		}
+		cursor = next
+	}
+}
+
+// MessagesPager returns a Pager of the messages matching params, its records are *messages.Message
+func MessagesPager(service *messages.ApiService, params *messages.QueryParams) Pager {
+	return func(cursor string) ([]interface{}, string, error) {
+		p := messages.QueryParams{}
+		if params != nil {
+			p = *params
+		}
+		p.Cursor = cursor
+		resp, err := service.Get(&p)
+		if err != nil {
+			return nil, "", err
+		}
+		records := make([]interface{}, len(resp.Results))
+		for i := range resp.Results {
+			records[i] = &resp.Results[i]
+		}
+		return records, resp.NextCursor(), nil
+	}
+}
+
+// FlowsPager returns a Pager of the flows matching params, its records are *flows.Flow
+func FlowsPager(service *flows.ApiService, params *flows.QueryParams) Pager {
+	return func(cursor string) ([]interface{}, string, error) {
+		p := flows.QueryParams{}
+		if params != nil {
+			p = *params
+		}
+		p.Cursor = cursor
+		resp, err := service.Get(&p)
+		if err != nil {
+			return nil, "", err
+		}
+		records := make([]interface{}, len(resp.Results))
+		for i := range resp.Results {
+			records[i] = &resp.Results[i]
+		}
+		return records, resp.NextCursor(), nil
+	}
+}
+
+// FlowStartsPager returns a Pager of the flow starts matching params, its records are *flowstarts.FlowStart
+func FlowStartsPager(service *flowstarts.ApiService, params *flowstarts.QueryParams) Pager {
+	return func(cursor string) ([]interface{}, string, error) {
+		p := flowstarts.QueryParams{}
+		if params != nil {
+			p = *params
+		}
+		p.Cursor = cursor
+		resp, err := service.Get(&p)
+		if err != nil {
+			return nil, "", err
+		}
+		records := make([]interface{}, len(resp.Results))
+		for i := range resp.Results {
+			records[i] = &resp.Results[i]
+		}
+		return records, resp.NextCursor(), nil
+	}
+}
@@ -0,0 +1,233 @@
+// Package export streams records of the messages, flows and flow starts endpoints, or any other record
+// type, into CSV, NDJSON or Parquet files, with column selection and rotation of files by size.
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Formats records can be exported in
+const (
+	FormatCSV     = "csv"
+	FormatNDJSON  = "ndjson"
+	FormatParquet = "parquet"
+)
+
+// Options configures a Writer
+type Options struct {
+	Format string
+	// Columns are the names of the columns to write in order, all of them are written when empty
+	Columns []string
+	// Flattening is how nested objects and lists are turned into columns, nested objects are split into
+	// columns and lists are written as JSON when nil
+	Flattening *Flattening
+	// MaxBytes is the size after which a new file is started, files aren't rotated when 0. Files may be
+	// slightly bigger as rows aren't split, and for Parquet the size of buffered rows is estimated.
+	MaxBytes int64
+	// Open returns the file with the given index, starting at 0
+	Open func(index int) (io.WriteCloser, error)
+}
+
+// FileOpener returns an Open function creating files named after a pattern with the file index, e.g.
+// "exports/messages-%04d.csv", creating their directory if it doesn't exist
+func FileOpener(pattern string) func(index int) (io.WriteCloser, error) {
+	return func(index int) (io.WriteCloser, error) {
+		path := fmt.Sprintf(pattern, index)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		return os.Create(path)
+	}
+}
+
+// encoder writes rows in a format to a file
+type encoder interface {
+	write(row []interface{}) error
+	// size returns the number of bytes of the file so far
+	size() int64
+	close() error
+}
+
+// Writer writes records of a type as rows, rotating files by size
+type Writer struct {
+	recordType reflect.Type
+	columns    []column
+	opts       Options
+	separator  string
+
+	file    *countingWriter
+	encoder encoder
+	files   int
+	rows    int
+}
+
+// NewWriter returns a Writer for records of the type of record, e.g. messages.Message{}
+func NewWriter(record interface{}, opts *Options) (*Writer, error) {
+	if opts == nil || opts.Open == nil {
+		return nil, errors.New("export options must have an Open function")
+	}
+	o := *opts
+	switch o.Format {
+	case FormatCSV, FormatNDJSON, FormatParquet:
+	default:
+		return nil, errors.Errorf("unknown export format %q", o.Format)
+	}
+	if o.Flattening == nil {
+		o.Flattening = &Flattening{Objects: ObjectsColumns, Lists: ListsJSON}
+	}
+
+	recordType := reflect.TypeOf(record)
+	for recordType != nil && recordType.Kind() == reflect.Ptr {
+		recordType = recordType.Elem()
+	}
+	if recordType == nil || recordType.Kind() != reflect.Struct {
+		return nil, errors.Errorf("can't export records of type %T", record)
+	}
+
+	columns := columnsOf(recordType, o.Flattening)
+	if len(o.Columns) > 0 {
+		selected := make([]column, 0, len(o.Columns))
+		for _, name := range o.Columns {
+			c := findColumn(columns, name)
+			if c == nil {
+				return nil, errors.Errorf("%s records have no column %s", recordType.Name(), name)
+			}
+			selected = append(selected, *c)
+		}
+		columns = selected
+	}
+
+	separator := o.Flattening.Separator
+	if separator == "" {
+		separator = DefaultSeparator
+	}
+	return &Writer{recordType: recordType, columns: columns, opts: o, separator: separator}, nil
+}
+
+func findColumn(columns []column, name string) *column {
+	for i := range columns {
+		if columns[i].name == name {
+			return &columns[i]
+		}
+	}
+	return nil
+}
+
+// Columns returns the names of the columns that are written
+func (w *Writer) Columns() []string {
+	names := make([]string, len(w.columns))
+	for i, c := range w.columns {
+		names[i] = c.name
+	}
+	return names
+}
+
+// Write writes a record, which must be of the type of the Writer or a pointer to it
+func (w *Writer) Write(record interface{}) error {
+	v := reflect.ValueOf(record)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Type() != w.recordType {
+		return errors.Errorf("can't write %T to a writer of %s records", record, w.recordType.Name())
+	}
+
+	if w.encoder == nil {
+		if err := w.open(); err != nil {
+			return err
+		}
+	}
+
+	row := make([]interface{}, len(w.columns))
+	for i := range w.columns {
+		row[i] = w.columns[i].value(v, w.separator)
+	}
+	if err := w.encoder.write(row); err != nil {
+		return err
+	}
+	w.rows++
+
+	if w.opts.MaxBytes > 0 && w.encoder.size() >= w.opts.MaxBytes {
+		return w.closeFile()
+	}
+	return nil
+}
+
+// Files returns the number of files started so far
+func (w *Writer) Files() int {
+	return w.files
+}
+
+// Rows returns the number of records written so far
+func (w *Writer) Rows() int {
+	return w.rows
+}
+
+// Close finishes the current file
+func (w *Writer) Close() error {
+	if w.encoder == nil {
+		return nil
+	}
+	return w.closeFile()
+}
+
+func (w *Writer) open() error {
+	f, err := w.opts.Open(w.files)
+	if err != nil {
+		return errors.Wrapf(err, "error opening export file %d", w.files)
+	}
+	w.file = &countingWriter{file: f, buffer: bufio.NewWriter(f)}
+	w.files++
+
+	switch w.opts.Format {
+	case FormatCSV:
+		w.encoder, err = newCSVEncoder(w.file, w.columns)
+	case FormatNDJSON:
+		w.encoder = newNDJSONEncoder(w.file, w.columns)
+	case FormatParquet:
+		w.encoder, err = newParquetEncoder(w.file, w.columns)
+	}
+	if err != nil {
+		w.file.Close()
+		w.encoder, w.file = nil, nil
+	}
+	return err
+}
+
+func (w *Writer) closeFile() error {
+	err := w.encoder.close()
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	w.encoder, w.file = nil, nil
+	return err
+}
+
+// countingWriter buffers writes to a file and counts the bytes written
+type countingWriter struct {
+	file   io.WriteCloser
+	buffer *bufio.Writer
+	count  int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.buffer.Write(p)
+	c.count += int64(n)
+	return n, err
+}
+
+// Close flushes the buffer and closes the file
+func (c *countingWriter) Close() error {
+	err := c.buffer.Flush()
+	if closeErr := c.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
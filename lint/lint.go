@@ -0,0 +1,121 @@
+// Package lint checks exported flow definitions for common authoring mistakes.
+package lint
+
+import (
+	"fmt"
+
+	"github.com/rasoro/rapidpro-api-go/v2/definitions"
+	"github.com/rasoro/rapidpro-api-go/v2/workspace"
+)
+
+// DefaultMaxSegments is the number of SMS segments a message may take when Options.MaxSegments isn't set
+const DefaultMaxSegments = 3
+
+// Rules an issue can be reported for
+const (
+	RuleUnreachableNode    = "unreachable_node"
+	RuleDanglingExit       = "dangling_exit"
+	RuleMissingTranslation = "missing_translation"
+	RuleSegmentBudget      = "segment_budget"
+	RuleInsecureWebhook    = "insecure_webhook"
+	RuleMissingField       = "missing_field"
+	RuleMissingGroup       = "missing_group"
+	RuleResultCollision    = "result_collision"
+)
+
+// Issue is a problem found in a flow definition
+type Issue struct {
+	Rule    string `json:"rule"`
+	Flow    string `json:"flow"`
+	Node    string `json:"node,omitempty"`
+	Action  string `json:"action,omitempty"`
+	Message string `json:"message"`
+}
+
+func (i Issue) String() string {
+	location := i.Flow
+	if i.Node != "" {
+		location += "/" + i.Node
+	}
+	if i.Action != "" {
+		location += "/" + i.Action
+	}
+	return fmt.Sprintf("%s: %s: %s", location, i.Rule, i.Message)
+}
+
+// Options configures the checks of the linter
+type Options struct {
+	// Languages are the workspace languages every message must be translated to
+	Languages []string
+	// MaxSegments is the number of SMS segments a message may take
+	MaxSegments int
+	// Fields and Groups are the ones that exist in the workspace, references to others are reported.
+	// When nil, Lint takes them from the export if it includes all dependencies, otherwise these checks
+	// are skipped.
+	Fields []definitions.Field
+	Groups []definitions.Group
+	// Dependencies are the dependencies the export passed to Lint was fetched with. Exports always list
+	// fields and groups, but they're only all there with definitions.DependenciesAll.
+	Dependencies string
+}
+
+// NewOptions returns the Options to lint the flows of a workspace
+func NewOptions(ws *workspace.Workspace) *Options {
+	return &Options{Languages: ws.Languages, MaxSegments: DefaultMaxSegments}
+}
+
+// Lint checks every flow of an export. Fields and groups missing from an export fetched with all
+// dependencies are reported as deleted, see Options.Dependencies.
+func Lint(export *definitions.Export, opts *Options) []Issue {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Dependencies == definitions.DependenciesAll {
+		if o.Fields == nil {
+			o.Fields = export.Fields
+		}
+		if o.Groups == nil {
+			o.Groups = export.Groups
+		}
+	}
+
+	var issues []Issue
+	for i := range export.Flows {
+		issues = append(issues, LintFlow(&export.Flows[i], &o)...)
+	}
+	return issues
+}
+
+// LintFlow checks a single flow definition
+func LintFlow(flow *definitions.Flow, opts *Options) []Issue {
+	if opts == nil {
+		opts = &Options{}
+	}
+	l := &linter{flow: flow, opts: opts}
+	l.checkReachability()
+	l.checkExits()
+	l.checkTranslations()
+	l.checkSegments()
+	l.checkWebhooks()
+	l.checkFields()
+	l.checkGroups()
+	l.checkResultNames()
+	return l.issues
+}
+
+type linter struct {
+	flow   *definitions.Flow
+	opts   *Options
+	issues []Issue
+}
+
+func (l *linter) report(rule, node, action, format string, args ...interface{}) {
+	l.issues = append(l.issues, Issue{
+		Rule:    rule,
+		Flow:    l.flow.UUID,
+		Node:    node,
+		Action:  action,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
@@ -0,0 +1,133 @@
+package lint
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rasoro/rapidpro-api-go/v2/definitions"
+	"github.com/rasoro/rapidpro-api-go/v2/workspace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	export := &definitions.Export{}
+	assert.NoError(t, json.Unmarshal([]byte(testExport), export))
+
+	opts := NewOptions(&workspace.Workspace{Languages: []string{"eng", "fra"}})
+	opts.Dependencies = definitions.DependenciesAll
+	issues := Lint(export, opts)
+
+	assert.Equal(t, []Issue{
+		{Rule: RuleUnreachableNode, Flow: "flow-1", Node: "node-orphan", Message: "node can't be reached from the start of the flow"},
+		{Rule: RuleDanglingExit, Flow: "flow-1", Node: "node-ask", Message: "exit exit-gone leads to missing node node-gone"},
+		{Rule: RuleDanglingExit, Flow: "flow-1", Node: "node-ask", Message: `category "Blue" uses missing exit exit-missing`},
+		{Rule: RuleDanglingExit, Flow: "flow-1", Node: "node-ask", Message: "exit exit-gone isn't used by any category"},
+		{Rule: RuleMissingTranslation, Flow: "flow-1", Node: "node-orphan", Action: "action-orphan", Message: "message has no fra translation"},
+		{Rule: RuleSegmentBudget, Flow: "flow-1", Node: "node-ask", Action: "action-ask", Message: "fra translation takes 4 segments, more than 3"},
+		{Rule: RuleInsecureWebhook, Flow: "flow-1", Node: "node-hook", Action: "action-hook", Message: "webhook http://example.com/hook isn't called over HTTPS"},
+		{Rule: RuleMissingField, Flow: "flow-1", Node: "node-hook", Action: "action-hook", Message: `field "nickname" doesn't exist`},
+		{Rule: RuleMissingField, Flow: "flow-1", Node: "node-hook", Action: "action-field", Message: `field "deleted" doesn't exist`},
+		{Rule: RuleMissingGroup, Flow: "flow-1", Node: "node-ask", Message: `group "Removed" doesn't exist`},
+		{Rule: RuleMissingGroup, Flow: "flow-1", Node: "node-hook", Action: "action-group", Message: `group "Deleted" doesn't exist`},
+		{Rule: RuleResultCollision, Flow: "flow-1", Node: "node-hook", Action: "action-result", Message: `result "color!" collides with "Color" on key color`},
+	}, issues)
+
+	assert.Equal(t, "flow-1/node-hook/action-hook: insecure_webhook: webhook http://example.com/hook isn't called over HTTPS", issues[6].String())
+}
+
+func TestLintFlowWithoutDependencies(t *testing.T) {
+	export := &definitions.Export{}
+	assert.NoError(t, json.Unmarshal([]byte(testExport), export))
+
+	for _, issue := range LintFlow(&export.Flows[0], nil) {
+		assert.NotEqual(t, RuleMissingField, issue.Rule)
+		assert.NotEqual(t, RuleMissingGroup, issue.Rule)
+		assert.NotEqual(t, RuleMissingTranslation, issue.Rule)
+	}
+}
+
+func TestLintExportWithoutDependencies(t *testing.T) {
+	export := &definitions.Export{}
+	assert.NoError(t, json.Unmarshal([]byte(testExport), export))
+
+	// exports fetched with dependencies=none still list fields and groups, but empty
+	export.Fields, export.Groups = []definitions.Field{}, []definitions.Group{}
+	for _, dependencies := range []string{"", definitions.DependenciesNone, definitions.DependenciesFlows} {
+		for _, issue := range Lint(export, &Options{Dependencies: dependencies}) {
+			assert.NotEqual(t, RuleMissingField, issue.Rule)
+			assert.NotEqual(t, RuleMissingGroup, issue.Rule)
+		}
+	}
+}
+
+func TestSegments(t *testing.T) {
+	assert.Equal(t, 0, Segments(""))
+	assert.Equal(t, 1, Segments(strings.Repeat("a", 160)))
+	assert.Equal(t, 2, Segments(strings.Repeat("a", 161)))
+	assert.Equal(t, 2, Segments(strings.Repeat("€", 81)))
+	assert.Equal(t, 1, Segments(strings.Repeat("ç", 70)))
+	assert.Equal(t, 2, Segments(strings.Repeat("ç", 71)))
+	assert.Equal(t, 3, Segments(strings.Repeat("😀", 68)))
+}
+
+var testExport = `{
+	"version": "13",
+	"flows": [
+		{
+			"uuid": "flow-1",
+			"name": "Registration",
+			"language": "eng",
+			"type": "messaging",
+			"localization": {
+				"fra": {
+					"action-ask": {"text": ["` + strings.Repeat("ç", 210) + `"]}
+				}
+			},
+			"nodes": [
+				{
+					"uuid": "node-ask",
+					"actions": [{"uuid": "action-ask", "type": "send_msg", "text": "What is your favorite color?"}],
+					"router": {
+						"type": "switch",
+						"operand": "@input.text",
+						"result_name": "Color",
+						"wait": {"type": "msg"},
+						"cases": [
+							{"uuid": "case-red", "type": "has_any_word", "arguments": ["red"], "category_uuid": "category-red"},
+							{"uuid": "case-group", "type": "has_group", "arguments": ["group-removed", "Removed"], "category_uuid": "category-blue"}
+						],
+						"categories": [
+							{"uuid": "category-red", "name": "Red", "exit_uuid": "exit-red"},
+							{"uuid": "category-blue", "name": "Blue", "exit_uuid": "exit-missing"}
+						],
+						"default_category_uuid": "category-red"
+					},
+					"exits": [
+						{"uuid": "exit-red", "destination_uuid": "node-hook"},
+						{"uuid": "exit-gone", "destination_uuid": "node-gone"}
+					]
+				},
+				{
+					"uuid": "node-hook",
+					"actions": [
+						{"uuid": "action-hook", "type": "call_webhook", "method": "POST", "url": "http://example.com/hook", "body": "@fields.nickname @fields.age"},
+						{"uuid": "action-field", "type": "set_contact_field", "field": {"key": "deleted", "name": "Deleted"}, "value": "yes"},
+						{"uuid": "action-group", "type": "add_contact_groups", "groups": [{"uuid": "group-customers", "name": "Customers"}, {"uuid": "group-deleted", "name": "Deleted"}]},
+						{"uuid": "action-result", "type": "set_run_result", "name": "color!", "value": "@input"}
+					],
+					"exits": [{"uuid": "exit-hook"}]
+				},
+				{
+					"uuid": "node-orphan",
+					"actions": [{"uuid": "action-orphan", "type": "send_msg", "text": "Nobody gets here"}],
+					"exits": [{"uuid": "exit-orphan"}]
+				}
+			]
+		}
+	],
+	"campaigns": [],
+	"triggers": [],
+	"fields": [{"key": "age", "name": "Age", "type": "numeric"}],
+	"groups": [{"uuid": "group-customers", "name": "Customers"}]
+}`
@@ -0,0 +1,272 @@
+package lint
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rasoro/rapidpro-api-go/v2/definitions"
+)
+
+// fieldRegex matches references to contact fields in expressions
+var fieldRegex = regexp.MustCompile(`(?i)@(?:contact\.)?fields\.([a-z0-9_]+)`)
+
+// checkReachability reports nodes that can't be reached by following exits from the first node
+func (l *linter) checkReachability() {
+	if len(l.flow.Nodes) == 0 {
+		return
+	}
+	reached := map[string]bool{l.flow.Nodes[0].UUID: true}
+	queue := []string{l.flow.Nodes[0].UUID}
+	for len(queue) > 0 {
+		node := l.flow.Node(queue[0])
+		queue = queue[1:]
+		if node == nil {
+			continue
+		}
+		for _, exit := range node.Exits {
+			if exit.DestinationUUID != "" && !reached[exit.DestinationUUID] {
+				reached[exit.DestinationUUID] = true
+				queue = append(queue, exit.DestinationUUID)
+			}
+		}
+	}
+
+	for _, node := range l.flow.Nodes {
+		if !reached[node.UUID] {
+			l.report(RuleUnreachableNode, node.UUID, "", "node can't be reached from the start of the flow")
+		}
+	}
+}
+
+// checkExits reports exits leading to missing nodes and mismatches between router categories and exits
+func (l *linter) checkExits() {
+	for _, node := range l.flow.Nodes {
+		for _, exit := range node.Exits {
+			if exit.DestinationUUID != "" && l.flow.Node(exit.DestinationUUID) == nil {
+				l.report(RuleDanglingExit, node.UUID, "", "exit %s leads to missing node %s", exit.UUID, exit.DestinationUUID)
+			}
+		}
+		if node.Router == nil {
+			continue
+		}
+
+		used := make(map[string]bool, len(node.Exits))
+		for _, category := range node.Router.Categories {
+			if node.Exit(category.ExitUUID) == nil {
+				l.report(RuleDanglingExit, node.UUID, "", "category %q uses missing exit %s", category.Name, category.ExitUUID)
+			}
+			used[category.ExitUUID] = true
+		}
+		for _, exit := range node.Exits {
+			if !used[exit.UUID] {
+				l.report(RuleDanglingExit, node.UUID, "", "exit %s isn't used by any category", exit.UUID)
+			}
+		}
+	}
+}
+
+// checkTranslations reports messages that aren't translated to every workspace language
+func (l *linter) checkTranslations() {
+	for _, lang := range l.opts.Languages {
+		if lang == l.flow.Language {
+			continue
+		}
+		for _, node := range l.flow.Nodes {
+			for _, action := range node.Actions {
+				if !isMessage(&action) || action.Text == "" {
+					continue
+				}
+				if translation(l.flow, lang, action.UUID) == "" {
+					l.report(RuleMissingTranslation, node.UUID, action.UUID, "message has no %s translation", lang)
+				}
+			}
+		}
+	}
+}
+
+// checkSegments reports messages, and their translations, that take more SMS segments than allowed
+func (l *linter) checkSegments() {
+	if l.flow.Type == definitions.FlowTypeVoice {
+		return
+	}
+	max := l.opts.MaxSegments
+	if max <= 0 {
+		max = DefaultMaxSegments
+	}
+
+	for _, node := range l.flow.Nodes {
+		for _, action := range node.Actions {
+			if action.Type != definitions.ActionTypeSendMsg && action.Type != definitions.ActionTypeSendBroadcast {
+				continue
+			}
+			if n := Segments(action.Text); n > max {
+				l.report(RuleSegmentBudget, node.UUID, action.UUID, "message takes %d segments, more than %d", n, max)
+			}
+			for _, lang := range sortedLanguages(l.flow.Localization) {
+				if n := Segments(translation(l.flow, lang, action.UUID)); n > max {
+					l.report(RuleSegmentBudget, node.UUID, action.UUID, "%s translation takes %d segments, more than %d", lang, n, max)
+				}
+			}
+		}
+	}
+}
+
+// checkWebhooks reports webhooks called over plain HTTP
+func (l *linter) checkWebhooks() {
+	for _, node := range l.flow.Nodes {
+		for _, action := range node.Actions {
+			if action.Type == definitions.ActionTypeCallWebhook && strings.HasPrefix(strings.ToLower(action.URL), "http://") {
+				l.report(RuleInsecureWebhook, node.UUID, action.UUID, "webhook %s isn't called over HTTPS", action.URL)
+			}
+		}
+	}
+}
+
+// checkFields reports references to fields that don't exist in the workspace
+func (l *linter) checkFields() {
+	if l.opts.Fields == nil {
+		return
+	}
+	fields := make(map[string]bool, len(l.opts.Fields))
+	for _, field := range l.opts.Fields {
+		fields[strings.ToLower(field.Key)] = true
+	}
+
+	for _, node := range l.flow.Nodes {
+		for _, action := range node.Actions {
+			var keys []string
+			if action.Type == definitions.ActionTypeSetContactField && action.Field != nil {
+				keys = append(keys, action.Field.Key)
+			}
+			keys = append(keys, referencedFields(action.Text, action.URL, action.Body, action.Value, action.Subject)...)
+			for _, key := range unique(keys) {
+				if !fields[strings.ToLower(key)] {
+					l.report(RuleMissingField, node.UUID, action.UUID, "field %q doesn't exist", key)
+				}
+			}
+		}
+		if node.Router == nil {
+			continue
+		}
+		texts := []string{node.Router.Operand}
+		for _, c := range node.Router.Cases {
+			texts = append(texts, c.Arguments...)
+		}
+		for _, key := range unique(referencedFields(texts...)) {
+			if !fields[strings.ToLower(key)] {
+				l.report(RuleMissingField, node.UUID, "", "field %q doesn't exist", key)
+			}
+		}
+	}
+}
+
+// checkGroups reports references to groups that don't exist in the workspace
+func (l *linter) checkGroups() {
+	if l.opts.Groups == nil {
+		return
+	}
+	uuids := make(map[string]bool, len(l.opts.Groups))
+	names := make(map[string]bool, len(l.opts.Groups))
+	for _, group := range l.opts.Groups {
+		uuids[group.UUID] = true
+		names[strings.ToLower(group.Name)] = true
+	}
+
+	for _, node := range l.flow.Nodes {
+		for _, action := range node.Actions {
+			for _, group := range action.Groups {
+				if group.UUID != "" && !uuids[group.UUID] || group.UUID == "" && group.Name != "" && !names[strings.ToLower(group.Name)] {
+					l.report(RuleMissingGroup, node.UUID, action.UUID, "group %q doesn't exist", group.Name)
+				}
+			}
+		}
+		if node.Router == nil {
+			continue
+		}
+		for _, c := range node.Router.Cases {
+			if c.Type == "has_group" && len(c.Arguments) > 0 && !uuids[c.Arguments[0]] {
+				name := c.Arguments[0]
+				if len(c.Arguments) > 1 {
+					name = c.Arguments[1]
+				}
+				l.report(RuleMissingGroup, node.UUID, "", "group %q doesn't exist", name)
+			}
+		}
+	}
+}
+
+// checkResultNames reports different result names that are saved under the same key
+func (l *linter) checkResultNames() {
+	names := make(map[string]string)
+	check := func(node, action, name string) {
+		if name == "" {
+			return
+		}
+		key := definitions.ResultKey(name)
+		if other, ok := names[key]; ok && other != name {
+			l.report(RuleResultCollision, node, action, "result %q collides with %q on key %s", name, other, key)
+			return
+		}
+		names[key] = name
+	}
+
+	for _, node := range l.flow.Nodes {
+		for _, action := range node.Actions {
+			if action.Type == definitions.ActionTypeSetRunResult {
+				check(node.UUID, action.UUID, action.Name)
+			}
+		}
+		if node.Router != nil {
+			check(node.UUID, "", node.Router.ResultName)
+		}
+	}
+}
+
+func isMessage(action *definitions.Action) bool {
+	switch action.Type {
+	case definitions.ActionTypeSendMsg, definitions.ActionTypeSendBroadcast, definitions.ActionTypeSayMsg:
+		return true
+	}
+	return false
+}
+
+// translation returns the translated text of an action, or an empty string if it isn't translated
+func translation(flow *definitions.Flow, lang, uuid string) string {
+	text := flow.Localization[lang][uuid]["text"]
+	if len(text) == 0 {
+		return ""
+	}
+	return text[0]
+}
+
+func referencedFields(texts ...string) []string {
+	var keys []string
+	for _, text := range texts {
+		for _, match := range fieldRegex.FindAllStringSubmatch(text, -1) {
+			keys = append(keys, match[1])
+		}
+	}
+	return keys
+}
+
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := values[:0]
+	for _, v := range values {
+		if !seen[strings.ToLower(v)] {
+			seen[strings.ToLower(v)] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func sortedLanguages(localization definitions.Localization) []string {
+	langs := make([]string, 0, len(localization))
+	for lang := range localization {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
@@ -0,0 +1,56 @@
+package lint
+
+// gsm7 are the characters of the GSM 03.38 basic character set
+var gsm7 = makeCharset("@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà")
+
+// gsm7Extended are the characters of the GSM 03.38 extension table, which take two septets
+var gsm7Extended = makeCharset("\f^{}\\[~]|€")
+
+func makeCharset(chars string) map[rune]bool {
+	charset := make(map[rune]bool)
+	for _, r := range chars {
+		charset[r] = true
+	}
+	return charset
+}
+
+// Segments returns the number of SMS segments a text is sent in. Texts with characters outside of
+// the GSM 03.38 character set are sent as UCS-2, which fits fewer characters in each segment.
+func Segments(text string) int {
+	if text == "" {
+		return 0
+	}
+
+	septets, ucs2 := 0, false
+	for _, r := range text {
+		switch {
+		case gsm7[r]:
+			septets++
+		case gsm7Extended[r]:
+			septets += 2
+		default:
+			ucs2 = true
+		}
+	}
+
+	if ucs2 {
+		units := 0
+		for _, r := range text {
+			if r > 0xFFFF {
+				units += 2
+			} else {
+				units++
+			}
+		}
+		return segmentCount(units, 70, 67)
+	}
+	return segmentCount(septets, 160, 153)
+}
+
+func segmentCount(length, single, multi int) int {
+	if length <= single {
+		return 1
+	}
+	return (length + multi - 1) / multi
+}
@@ -0,0 +1,158 @@
+// Package mirror writes synced workspace data into a local SQLite database so it can be queried
+// offline with SQL, see Schema for the tables. It works with any database/sql SQLite driver.
+package mirror
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/syncer"
+)
+
+// Mirror is a SQLite database mirroring the records of endpoints. Its Handle method is a syncer.Handler
+// and it's a syncer.CheckpointStore, so checkpoints are kept alongside the data.
+type Mirror struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// New creates the schema in db if it doesn't exist yet and returns a Mirror writing to it
+func New(db *sql.DB) (*Mirror, error) {
+	if _, err := db.Exec(Schema); err != nil {
+		return nil, errors.Wrap(err, "error creating schema")
+	}
+	return &Mirror{db: db, now: time.Now}, nil
+}
+
+// Handle upserts records of an endpoint, by uuid or id for the endpoints that have a table and by
+// endpoint and key in the records table for others, and updates the sync metadata of the endpoint
+func (m *Mirror) Handle(endpoint string, records []syncer.Record) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := m.write(tx, endpoint, records); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (m *Mirror) write(tx *sql.Tx, endpoint string, records []syncer.Record) error {
+	var highWater time.Time
+	t := tables[endpoint]
+	for i := range records {
+		record := &records[i]
+		raw, err := json.Marshal(record.Value)
+		if err != nil {
+			return errors.Wrapf(err, "error encoding %s record %s", endpoint, record.Key)
+		}
+
+		values, ok := []interface{}(nil), false
+		if t != nil {
+			values, ok = t.values(record)
+		}
+		if ok {
+			_, err = tx.Exec(t.upsert(), append(values, string(raw))...)
+		} else {
+			_, err = tx.Exec(`INSERT INTO records (endpoint, key, modified_on, raw) VALUES (?, ?, ?, ?)
+				ON CONFLICT (endpoint, key) DO UPDATE SET modified_on = excluded.modified_on, raw = excluded.raw`,
+				endpoint, record.Key, timeText(&record.ModifiedOn), string(raw))
+		}
+		if err != nil {
+			return errors.Wrapf(err, "error writing %s record %s", endpoint, record.Key)
+		}
+
+		if record.ModifiedOn.After(highWater) {
+			highWater = record.ModifiedOn
+		}
+	}
+
+	// records are counted rather than accumulated, so pages delivered again after a restart aren't counted twice
+	count := `SELECT count(*) FROM records WHERE endpoint = ?`
+	if t != nil {
+		count = `SELECT (SELECT count(*) FROM ` + t.name + `) + (` + count + `)`
+	}
+	var n int
+	if err := tx.QueryRow(count, endpoint).Scan(&n); err != nil {
+		return errors.Wrapf(err, "error counting %s records", endpoint)
+	}
+
+	_, err := tx.Exec(`INSERT INTO sync_metadata (endpoint, records, high_water, synced_on) VALUES (?, ?, ?, ?)
+		ON CONFLICT (endpoint) DO UPDATE SET
+			records = excluded.records,
+			high_water = CASE WHEN high_water IS NULL OR excluded.high_water > high_water THEN excluded.high_water ELSE high_water END,
+			synced_on = excluded.synced_on`,
+		endpoint, n, timeText(&highWater), timeText(timePtr(m.now())))
+	return errors.Wrapf(err, "error writing sync metadata of %s", endpoint)
+}
+
+// Load returns the checkpoint of an endpoint stored in its sync metadata
+func (m *Mirror) Load(endpoint string) (*syncer.Checkpoint, error) {
+	var data sql.NullString
+	err := m.db.QueryRow(`SELECT checkpoint FROM sync_metadata WHERE endpoint = ?`, endpoint).Scan(&data)
+	if err == sql.ErrNoRows || err == nil && !data.Valid {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := &syncer.Checkpoint{}
+	return checkpoint, json.Unmarshal([]byte(data.String), checkpoint)
+}
+
+// Save stores the checkpoint of an endpoint in its sync metadata
+func (m *Mirror) Save(endpoint string, checkpoint *syncer.Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	_, err = m.db.Exec(`INSERT INTO sync_metadata (endpoint, checkpoint) VALUES (?, ?)
+		ON CONFLICT (endpoint) DO UPDATE SET checkpoint = excluded.checkpoint`, endpoint, string(data))
+	return err
+}
+
+// Metadata is the sync metadata of an endpoint
+type Metadata struct {
+	Endpoint string
+	// Records is the number of records of the endpoint in the mirror
+	Records   int
+	HighWater *time.Time
+	SyncedOn  *time.Time
+}
+
+// Metadata returns the sync metadata of an endpoint, or nil if it was never synced
+func (m *Mirror) Metadata(endpoint string) (*Metadata, error) {
+	var highWater, syncedOn sql.NullString
+	metadata := &Metadata{Endpoint: endpoint}
+	err := m.db.QueryRow(`SELECT records, high_water, synced_on FROM sync_metadata WHERE endpoint = ?`, endpoint).
+		Scan(&metadata.Records, &highWater, &syncedOn)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if metadata.HighWater, err = parseTime(highWater); err != nil {
+		return nil, err
+	}
+	if metadata.SyncedOn, err = parseTime(syncedOn); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+func parseTime(s sql.NullString) (*time.Time, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	t, err := time.Parse(timeLayout, s.String)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func timePtr(t time.Time) *time.Time { return &t }
@@ -0,0 +1,196 @@
+package mirror
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/syncer"
+	"github.com/rasoro/rapidpro-api-go/v2/flows"
+	"github.com/rasoro/rapidpro-api-go/v2/flowstarts"
+	"github.com/rasoro/rapidpro-api-go/v2/messages"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2022, 3, 2, 8, 0, 0, 0, time.UTC)
+
+func newMirror(t *testing.T) (*Mirror, *sql.DB) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	db.SetMaxOpenConns(1)
+
+	m, err := New(db)
+	assert.NoError(t, err)
+	m.now = func() time.Time { return now }
+
+	// creating the schema again is a noop
+	_, err = New(db)
+	assert.NoError(t, err)
+	return m, db
+}
+
+func decode(t *testing.T, data string, v interface{}) {
+	assert.NoError(t, json.Unmarshal([]byte(data), v))
+}
+
+func TestHandle(t *testing.T) {
+	m, db := newMirror(t)
+	defer db.Close()
+
+	flow := &flows.Flow{}
+	decode(t, `{"uuid": "f5901b62-ba76-4003-9c62-72fdacc1b7b7", "name": "Survey", "type": "message", "archived": false,
+		"labels": [{"uuid": "5a4eb79e-1b1f-4ae3-8700-09384cca385f", "name": "Important"}],
+		"runs": {"active": 1, "completed": 2, "interrupted": 3, "expired": 4},
+		"created_on": "2022-01-01T00:00:00Z", "modified_on": "2022-03-01T10:00:02.5Z"}`, flow)
+	start := &flowstarts.FlowStart{}
+	decode(t, `{"uuid": "09d23a05-47fe-11e4-bfe9-b8f6b119e9ab", "flow": {"uuid": "f5901b62-ba76-4003-9c62-72fdacc1b7b7", "name": "Survey"},
+		"status": "complete", "params": {"first_name": "Ryan"}, "created_on": "2022-03-01T10:00:00Z"}`, start)
+	msg := &messages.Message{}
+	decode(t, `{"id": 4105426, "contact": {"uuid": "d33e9ad5-5c35-414c-abd4-e7451c69ff1d", "name": "Bob McFlow"}, "urn": "tel:+250788123123",
+		"direction": "out", "status": "wired", "text": "How are you?", "attachments": [], "labels": [],
+		"created_on": "2022-03-01T10:00:01Z", "modified_on": "2022-03-01T10:00:03Z"}`, msg)
+
+	assert.NoError(t, m.Handle(syncer.EndpointFlows, []syncer.Record{{Key: flow.UUID, ModifiedOn: flow.ModifiedOn, Value: flow}}))
+	assert.NoError(t, m.Handle(syncer.EndpointFlowStarts, []syncer.Record{{Key: start.UUID, ModifiedOn: *start.CreatedOn, Value: start}}))
+	assert.NoError(t, m.Handle(syncer.EndpointMessages, []syncer.Record{{Key: "4105426", ModifiedOn: *msg.ModifiedOn, Value: msg}}))
+	assert.NoError(t, m.Handle("contacts", []syncer.Record{{Key: "contact-1", ModifiedOn: *msg.ModifiedOn, Value: map[string]string{"name": "Bob"}}}))
+
+	var name, labels, modifiedOn string
+	var completed int
+	assert.NoError(t, db.QueryRow(`SELECT name, labels, runs_completed, modified_on FROM flows WHERE uuid = ?`, flow.UUID).
+		Scan(&name, &labels, &completed, &modifiedOn))
+	assert.Equal(t, "Survey", name)
+	assert.Equal(t, `[{"uuid":"5a4eb79e-1b1f-4ae3-8700-09384cca385f","name":"Important"}]`, labels)
+	assert.Equal(t, 2, completed)
+	assert.Equal(t, "2022-03-01T10:00:02.500000Z", modifiedOn)
+
+	var firstName string
+	var startModifiedOn sql.NullString
+	assert.NoError(t, db.QueryRow(`SELECT json_extract(params, '$.first_name'), modified_on FROM flow_starts WHERE flow_uuid = ?`, flow.UUID).
+		Scan(&firstName, &startModifiedOn))
+	assert.Equal(t, "Ryan", firstName)
+	assert.False(t, startModifiedOn.Valid)
+
+	var contactName string
+	var broadcast sql.NullInt64
+	assert.NoError(t, db.QueryRow(`SELECT contact_name, broadcast FROM messages WHERE id = 4105426`).Scan(&contactName, &broadcast))
+	assert.Equal(t, "Bob McFlow", contactName)
+	assert.False(t, broadcast.Valid)
+
+	var raw string
+	assert.NoError(t, db.QueryRow(`SELECT raw FROM records WHERE endpoint = 'contacts' AND key = 'contact-1'`).Scan(&raw))
+	assert.Equal(t, `{"name":"Bob"}`, raw)
+
+	// records are upserted and counted once
+	flow.Name = "Renamed"
+	flow.ModifiedOn = time.Date(2022, 3, 1, 10, 0, 2, 0, time.UTC)
+	assert.NoError(t, m.Handle(syncer.EndpointFlows, []syncer.Record{{Key: flow.UUID, ModifiedOn: flow.ModifiedOn, Value: flow}}))
+
+	var count int
+	assert.NoError(t, db.QueryRow(`SELECT count(*), max(name) FROM flows`).Scan(&count, &name))
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "Renamed", name)
+
+	metadata, err := m.Metadata(syncer.EndpointFlows)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, metadata.Records)
+	assert.Equal(t, time.Date(2022, 3, 1, 10, 0, 2, 500000000, time.UTC), *metadata.HighWater)
+	assert.Equal(t, now, *metadata.SyncedOn)
+
+	metadata, err = m.Metadata("runs")
+	assert.NoError(t, err)
+	assert.Nil(t, metadata)
+}
+
+func TestCheckpoints(t *testing.T) {
+	m, db := newMirror(t)
+	defer db.Close()
+
+	checkpoint, err := m.Load(syncer.EndpointMessages)
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	// writing records before a checkpoint is saved leaves the checkpoint empty
+	assert.NoError(t, m.Handle(syncer.EndpointMessages, []syncer.Record{{Key: "1", Value: &messages.Message{ID: 1}}}))
+	checkpoint, err = m.Load(syncer.EndpointMessages)
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	saved := &syncer.Checkpoint{After: time.Date(2022, 3, 1, 10, 0, 3, 0, time.UTC), Boundary: map[string]time.Time{"1": time.Date(2022, 3, 1, 10, 0, 3, 0, time.UTC)}}
+	assert.NoError(t, m.Save(syncer.EndpointMessages, saved))
+	checkpoint, err = m.Load(syncer.EndpointMessages)
+	assert.NoError(t, err)
+	assert.Equal(t, saved, checkpoint)
+
+	metadata, err := m.Metadata(syncer.EndpointMessages)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, metadata.Records)
+}
+
+type staticSource struct {
+	records []syncer.Record
+}
+
+func (s *staticSource) Endpoint() string { return "globals" }
+
+func (s *staticSource) Fetch(ctx context.Context, after *time.Time, cursor string) (*syncer.Page, error) {
+	return &syncer.Page{Records: s.records}, nil
+}
+
+func TestSyncIntoMirror(t *testing.T) {
+	m, db := newMirror(t)
+	defer db.Close()
+
+	modifiedOn := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	source := &staticSource{records: []syncer.Record{
+		{Key: "org_name", ModifiedOn: modifiedOn, Value: map[string]string{"value": "Acme"}},
+	}}
+	engine := syncer.NewEngine(m, m.Handle, source)
+	assert.NoError(t, engine.Sync(context.Background()))
+	assert.NoError(t, engine.Sync(context.Background()))
+
+	metadata, err := m.Metadata("globals")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, metadata.Records)
+
+	checkpoint, err := m.Load("globals")
+	assert.NoError(t, err)
+	assert.Equal(t, modifiedOn, checkpoint.After)
+}
+
+// crashingStore fails to save the first checkpoint, like a process stopping between handling a page and saving it
+type crashingStore struct {
+	*Mirror
+	crashed bool
+}
+
+func (s *crashingStore) Save(endpoint string, checkpoint *syncer.Checkpoint) error {
+	if !s.crashed {
+		s.crashed = true
+		return errors.New("crash")
+	}
+	return s.Mirror.Save(endpoint, checkpoint)
+}
+
+func TestRedeliveredPage(t *testing.T) {
+	m, db := newMirror(t)
+	defer db.Close()
+
+	modifiedOn := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	source := &staticSource{records: []syncer.Record{
+		{Key: "org_name", ModifiedOn: modifiedOn, Value: map[string]string{"value": "Acme"}},
+		{Key: "org_phone", ModifiedOn: modifiedOn, Value: map[string]string{"value": "+250788123123"}},
+	}}
+	engine := syncer.NewEngine(&crashingStore{Mirror: m}, m.Handle, source)
+	assert.EqualError(t, engine.Sync(context.Background()), "error saving checkpoint of globals: crash")
+	assert.NoError(t, engine.Sync(context.Background()))
+
+	// the page was written twice but its records are only counted once
+	metadata, err := m.Metadata("globals")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, metadata.Records)
+}
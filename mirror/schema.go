@@ -0,0 +1,78 @@
+package mirror
+
+// Schema is the SQLite schema of the mirror. Timestamps are stored as RFC 3339 text in UTC with microseconds,
+// e.g. 2022-03-01T10:00:02.500000Z, which sorts chronologically and works with SQLite date functions. Lists
+// and objects are stored as JSON text, and every table keeps the whole record as JSON in its raw column.
+const Schema = `
+-- sync_metadata has a row per synced endpoint
+CREATE TABLE IF NOT EXISTS sync_metadata (
+	endpoint    TEXT PRIMARY KEY,
+	records     INTEGER NOT NULL DEFAULT 0, -- number of records of the endpoint in the mirror
+	high_water  TEXT,                       -- latest modified_on written
+	synced_on   TEXT,                       -- when records were last written
+	checkpoint  TEXT                        -- JSON sync checkpoint, see syncer.Checkpoint
+);
+
+CREATE TABLE IF NOT EXISTS flows (
+	uuid              TEXT PRIMARY KEY,
+	name              TEXT,
+	type              TEXT,
+	archived          INTEGER,
+	expires           INTEGER,
+	runs_active       INTEGER,
+	runs_completed    INTEGER,
+	runs_interrupted  INTEGER,
+	runs_expired      INTEGER,
+	labels            TEXT,
+	created_on        TEXT,
+	modified_on       TEXT,
+	raw               TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS flow_starts (
+	uuid                  TEXT PRIMARY KEY,
+	flow_uuid             TEXT,
+	flow_name             TEXT,
+	status                TEXT,
+	restart_participants  INTEGER,
+	exclude_active        INTEGER,
+	params                TEXT,
+	created_on            TEXT,
+	modified_on           TEXT,
+	raw                   TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS messages (
+	id            INTEGER PRIMARY KEY,
+	broadcast     INTEGER,
+	contact_uuid  TEXT,
+	contact_name  TEXT,
+	urn           TEXT,
+	channel_uuid  TEXT,
+	channel_name  TEXT,
+	direction     TEXT,
+	type          TEXT,
+	status        TEXT,
+	visibility    TEXT,
+	text          TEXT,
+	attachments   TEXT,
+	labels        TEXT,
+	created_on    TEXT,
+	sent_on       TEXT,
+	modified_on   TEXT,
+	raw           TEXT NOT NULL
+);
+
+-- records holds the records of endpoints that don't have a table of their own
+CREATE TABLE IF NOT EXISTS records (
+	endpoint     TEXT NOT NULL,
+	key          TEXT NOT NULL,
+	modified_on  TEXT,
+	raw          TEXT NOT NULL,
+	PRIMARY KEY (endpoint, key)
+);
+
+CREATE INDEX IF NOT EXISTS flow_starts_flow ON flow_starts (flow_uuid);
+CREATE INDEX IF NOT EXISTS messages_contact ON messages (contact_uuid);
+CREATE INDEX IF NOT EXISTS messages_modified_on ON messages (modified_on);
+`
@@ -0,0 +1,101 @@
+package mirror
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/rasoro/rapidpro-api-go/syncer"
+	"github.com/rasoro/rapidpro-api-go/v2/flows"
+	"github.com/rasoro/rapidpro-api-go/v2/flowstarts"
+	"github.com/rasoro/rapidpro-api-go/v2/messages"
+)
+
+// table is a table records of an endpoint are upserted into
+type table struct {
+	name    string
+	columns []string
+	// values returns the values of the columns for a record, before its raw JSON
+	values func(record *syncer.Record) ([]interface{}, bool)
+}
+
+// upsert returns the statement that inserts a row or updates the one with the same key, the first column
+func (t *table) upsert() string {
+	columns := append(append([]string{}, t.columns...), "raw")
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	updates := make([]string, 0, len(columns)-1)
+	for _, column := range columns[1:] {
+		updates = append(updates, column+" = excluded."+column)
+	}
+	return "INSERT INTO " + t.name + " (" + strings.Join(columns, ", ") + ") VALUES (" + placeholders + ") " +
+		"ON CONFLICT (" + columns[0] + ") DO UPDATE SET " + strings.Join(updates, ", ")
+}
+
+// tables are the tables of the endpoints that have one
+var tables = map[string]*table{
+	syncer.EndpointFlows: {
+		name: "flows",
+		columns: []string{"uuid", "name", "type", "archived", "expires", "runs_active", "runs_completed",
+			"runs_interrupted", "runs_expired", "labels", "created_on", "modified_on"},
+		values: func(record *syncer.Record) ([]interface{}, bool) {
+			f, ok := record.Value.(*flows.Flow)
+			if !ok {
+				return nil, false
+			}
+			return []interface{}{f.UUID, f.Name, f.Type, f.Archived, f.Expires, f.Runs.Active, f.Runs.Completed,
+				f.Runs.Interrupted, f.Runs.Expired, jsonText(f.Labels), timeText(&f.CreatedOn), timeText(&f.ModifiedOn)}, true
+		},
+	},
+	syncer.EndpointFlowStarts: {
+		name: "flow_starts",
+		columns: []string{"uuid", "flow_uuid", "flow_name", "status", "restart_participants", "exclude_active",
+			"params", "created_on", "modified_on"},
+		values: func(record *syncer.Record) ([]interface{}, bool) {
+			s, ok := record.Value.(*flowstarts.FlowStart)
+			if !ok {
+				return nil, false
+			}
+			var params interface{}
+			if len(s.Params) > 0 && string(s.Params) != "null" {
+				params = string(s.Params)
+			}
+			return []interface{}{s.UUID, s.Flow.UUID, s.Flow.Name, s.Status, s.RestartParticipants, s.ExcludeActive,
+				params, timeText(s.CreatedOn), timeText(s.ModifiedOn)}, true
+		},
+	},
+	syncer.EndpointMessages: {
+		name: "messages",
+		columns: []string{"id", "broadcast", "contact_uuid", "contact_name", "urn", "channel_uuid", "channel_name",
+			"direction", "type", "status", "visibility", "text", "attachments", "labels", "created_on", "sent_on", "modified_on"},
+		values: func(record *syncer.Record) ([]interface{}, bool) {
+			m, ok := record.Value.(*messages.Message)
+			if !ok {
+				return nil, false
+			}
+			var broadcast interface{}
+			if m.Broadcast != 0 {
+				broadcast = m.Broadcast
+			}
+			return []interface{}{m.ID, broadcast, m.Contact.UUID, m.Contact.Name, m.Urn, m.Channel.UUID, m.Channel.Name,
+				m.Direction, m.Type, m.Status, m.Visibility, m.Text, jsonText(m.Attachments), jsonText(m.Labels),
+				timeText(m.CreatedOn), timeText(m.SentOn), timeText(m.ModifiedOn)}, true
+		},
+	},
+}
+
+// timeLayout is how times are stored, with a fixed number of fractional digits so that they sort as text
+const timeLayout = "2006-01-02T15:04:05.000000Z07:00"
+
+// timeText formats a time as it's stored, a nil or zero time is stored as NULL
+func timeText(t *time.Time) interface{} {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(timeLayout)
+}
+
+// jsonText formats a list or object as it's stored
+func jsonText(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
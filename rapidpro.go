@@ -4,17 +4,57 @@ import (
 	"os"
 
 	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/rasoro/rapidpro-api-go/v2/archives"
+	"github.com/rasoro/rapidpro-api-go/v2/boundaries"
+	"github.com/rasoro/rapidpro-api-go/v2/channelevents"
+	"github.com/rasoro/rapidpro-api-go/v2/channels"
+	"github.com/rasoro/rapidpro-api-go/v2/classifiers"
+	"github.com/rasoro/rapidpro-api-go/v2/definitions"
 	"github.com/rasoro/rapidpro-api-go/v2/flows"
 	"github.com/rasoro/rapidpro-api-go/v2/flowstarts"
+	"github.com/rasoro/rapidpro-api-go/v2/globals"
+	"github.com/rasoro/rapidpro-api-go/v2/media"
+	"github.com/rasoro/rapidpro-api-go/v2/messages"
+	"github.com/rasoro/rapidpro-api-go/v2/optins"
+	"github.com/rasoro/rapidpro-api-go/v2/resthookevents"
+	"github.com/rasoro/rapidpro-api-go/v2/resthooks"
+	"github.com/rasoro/rapidpro-api-go/v2/resthooksubscribers"
+	"github.com/rasoro/rapidpro-api-go/v2/runs"
+	"github.com/rasoro/rapidpro-api-go/v2/ticketactions"
+	"github.com/rasoro/rapidpro-api-go/v2/ticketers"
+	"github.com/rasoro/rapidpro-api-go/v2/tickets"
+	"github.com/rasoro/rapidpro-api-go/v2/topics"
+	"github.com/rasoro/rapidpro-api-go/v2/users"
+	"github.com/rasoro/rapidpro-api-go/v2/workspace"
 )
 
 const apiURL = "https://localhost:8000/api"
 
 type RestClient struct {
 	*client.RequestHandler
-	Flows      *flows.ApiService
-	FlowStarts *flowstarts.ApiService
-	baseURL    string
+	Channels            *channels.ApiService
+	ChannelEvents       *channelevents.ApiService
+	Flows               *flows.ApiService
+	FlowStarts          *flowstarts.ApiService
+	Runs                *runs.ApiService
+	Archives            *archives.ApiService
+	Definitions         *definitions.ApiService
+	Globals             *globals.ApiService
+	Resthooks           *resthooks.ApiService
+	ResthookSubscribers *resthooksubscribers.ApiService
+	ResthookEvents      *resthookevents.ApiService
+	Tickets             *tickets.ApiService
+	TicketActions       *ticketactions.ApiService
+	Ticketers           *ticketers.ApiService
+	Topics              *topics.ApiService
+	Workspace           *workspace.ApiService
+	Users               *users.ApiService
+	Classifiers         *classifiers.ApiService
+	Boundaries          *boundaries.ApiService
+	Media               *media.ApiService
+	OptIns              *optins.ApiService
+	Messages            *messages.ApiService
+	baseURL             string
 }
 
 type ClientParams struct {
@@ -44,7 +84,27 @@ func NewRestClientWithParams(params ClientParams) *RestClient {
 		RequestHandler: requestHandler,
 	}
 
+	c.Channels = channels.NewService(c.RequestHandler, params.ApiURL)
+	c.ChannelEvents = channelevents.NewService(c.RequestHandler, params.ApiURL)
 	c.Flows = flows.NewService(c.RequestHandler, params.ApiURL)
 	c.FlowStarts = flowstarts.NewService(c.RequestHandler, params.ApiURL)
+	c.Runs = runs.NewService(c.RequestHandler, params.ApiURL)
+	c.Archives = archives.NewService(c.RequestHandler, params.ApiURL)
+	c.Definitions = definitions.NewService(c.RequestHandler, params.ApiURL)
+	c.Globals = globals.NewService(c.RequestHandler, params.ApiURL)
+	c.Resthooks = resthooks.NewService(c.RequestHandler, params.ApiURL)
+	c.ResthookSubscribers = resthooksubscribers.NewService(c.RequestHandler, params.ApiURL)
+	c.ResthookEvents = resthookevents.NewService(c.RequestHandler, params.ApiURL)
+	c.Tickets = tickets.NewService(c.RequestHandler, params.ApiURL)
+	c.TicketActions = ticketactions.NewService(c.RequestHandler, params.ApiURL)
+	c.Ticketers = ticketers.NewService(c.RequestHandler, params.ApiURL)
+	c.Topics = topics.NewService(c.RequestHandler, params.ApiURL)
+	c.Workspace = workspace.NewService(c.RequestHandler, params.ApiURL)
+	c.Users = users.NewService(c.RequestHandler, params.ApiURL)
+	c.Classifiers = classifiers.NewService(c.RequestHandler, params.ApiURL)
+	c.Boundaries = boundaries.NewService(c.RequestHandler, params.ApiURL)
+	c.Media = media.NewService(c.RequestHandler, params.ApiURL)
+	c.OptIns = optins.NewService(c.RequestHandler, params.ApiURL)
+	c.Messages = messages.NewService(c.RequestHandler, params.ApiURL)
 	return c
 }
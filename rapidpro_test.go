@@ -19,3 +19,13 @@ func TestClientNewRestClient(t *testing.T) {
 	client := NewRestClient()
 	assert.Equal(t, client.RequestHandler.Client.Token(), "token123")
 }
+
+func TestClientServices(t *testing.T) {
+	client := NewRestClientWithParams(ClientParams{
+		Token:  "token123",
+		ApiURL: "https://rapidpro.io/api",
+	})
+	assert.NotNil(t, client.Messages)
+	assert.NotNil(t, client.Flows)
+	assert.NotNil(t, client.FlowStarts)
+}
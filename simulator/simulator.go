@@ -0,0 +1,358 @@
+// Package simulator executes exported flow definitions offline against scripted contact inputs,
+// so that the routing of a flow can be tested without contacting the server.
+package simulator
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/v2/definitions"
+)
+
+// DefaultMaxSteps is the number of nodes a session can visit when Options.MaxSteps isn't set
+const DefaultMaxSteps = 100
+
+// Session statuses
+const (
+	StatusCompleted = "completed"
+	StatusWaiting   = "waiting"
+)
+
+// ErrMaxSteps is returned when a session visits more nodes than allowed, usually because of a loop
+var ErrMaxSteps = errors.New("session exceeded the maximum number of steps")
+
+// Contact is the simulated contact a flow is run for
+type Contact struct {
+	Name     string
+	Language string
+	URN      string
+	Fields   map[string]string
+	// Groups are the uuids of the groups the contact is in
+	Groups []string
+}
+
+// clone returns a copy of the contact that can be changed without affecting it, or an empty contact if it's nil
+func (c *Contact) clone() *Contact {
+	clone := &Contact{Fields: make(map[string]string)}
+	if c == nil {
+		return clone
+	}
+	clone.Name, clone.Language, clone.URN = c.Name, c.Language, c.URN
+	for key, value := range c.Fields {
+		clone.Fields[key] = value
+	}
+	clone.Groups = append([]string(nil), c.Groups...)
+	return clone
+}
+
+// InGroup returns whether the contact is in the group with the given uuid
+func (c *Contact) InGroup(uuid string) bool {
+	for _, group := range c.Groups {
+		if group == uuid {
+			return true
+		}
+	}
+	return false
+}
+
+// Message is a message sent to the contact
+type Message struct {
+	Node         string
+	Text         string
+	Attachments  []string
+	QuickReplies []string
+}
+
+// Result is a value saved by the flow
+type Result struct {
+	Name     string
+	Value    string
+	Category string
+	Input    string
+	Node     string
+}
+
+// Options configures a simulation
+type Options struct {
+	// Contact the flow is run for, which is copied so changes made by the flow are only seen in
+	// Session.Contact. A contact without fields or groups is used when nil.
+	Contact *Contact
+	// MaxSteps is the number of nodes the session can visit before it fails with ErrMaxSteps
+	MaxSteps int
+	// Rand picks the categories of random routers, a source seeded with 0 is used when nil
+	Rand *rand.Rand
+	// Webhook returns the response status of webhook calls, which it gets with their URL, body and headers
+	// evaluated. Every call succeeds with 200 when nil.
+	Webhook func(action *definitions.Action) int
+}
+
+// Session is the outcome of running a flow
+type Session struct {
+	Status   string
+	Contact  *Contact
+	Messages []Message
+	Results  map[string]*Result
+	// Path are the uuids of the visited nodes in order
+	Path []string
+	// Inputs is the number of inputs the session consumed
+	Inputs int
+}
+
+// Result returns the result saved with the given name or key, or nil if there isn't one
+func (s *Session) Result(name string) *Result {
+	return s.Results[definitions.ResultKey(name)]
+}
+
+// Run executes the flow from its first node, consuming an input at every wait. The session is
+// completed when the flow ends, or waiting if the inputs run out before that.
+func Run(flow *definitions.Flow, inputs []string, opts *Options) (*Session, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	r := &runner{
+		flow:   flow,
+		inputs: inputs,
+		opts:   opts,
+		rand:   opts.Rand,
+		session: &Session{
+			Contact: opts.Contact.clone(),
+			Results: make(map[string]*Result),
+		},
+	}
+	if r.rand == nil {
+		r.rand = rand.New(rand.NewSource(0))
+	}
+
+	if err := r.run(); err != nil {
+		return r.session, err
+	}
+	return r.session, nil
+}
+
+type runner struct {
+	flow    *definitions.Flow
+	inputs  []string
+	opts    *Options
+	rand    *rand.Rand
+	session *Session
+
+	input string
+}
+
+func (r *runner) run() error {
+	if len(r.flow.Nodes) == 0 {
+		r.session.Status = StatusCompleted
+		return nil
+	}
+
+	maxSteps := r.opts.MaxSteps
+	if maxSteps <= 0 {
+		maxSteps = DefaultMaxSteps
+	}
+
+	node := &r.flow.Nodes[0]
+	for {
+		if len(r.session.Path) == maxSteps {
+			return ErrMaxSteps
+		}
+		r.session.Path = append(r.session.Path, node.UUID)
+
+		for i := range node.Actions {
+			r.execute(node, &node.Actions[i])
+		}
+
+		exit, err := r.route(node)
+		if err != nil {
+			return err
+		}
+		if exit == nil {
+			r.session.Status = StatusWaiting
+			return nil
+		}
+		if exit.DestinationUUID == "" {
+			r.session.Status = StatusCompleted
+			return nil
+		}
+
+		node = r.flow.Node(exit.DestinationUUID)
+		if node == nil {
+			return errors.Errorf("exit %s leads to missing node %s", exit.UUID, exit.DestinationUUID)
+		}
+	}
+}
+
+func (r *runner) execute(node *definitions.Node, action *definitions.Action) {
+	contact := r.session.Contact
+
+	switch action.Type {
+	case definitions.ActionTypeSendMsg, definitions.ActionTypeSayMsg:
+		r.session.Messages = append(r.session.Messages, Message{
+			Node:         node.UUID,
+			Text:         r.evaluate(r.localize(action.UUID, "text", action.Text)),
+			Attachments:  r.localizeAll(action.UUID, "attachments", action.Attachments),
+			QuickReplies: r.localizeAll(action.UUID, "quick_replies", action.QuickReplies),
+		})
+	case definitions.ActionTypeSetRunResult:
+		r.saveResult(node, action.Name, r.evaluate(action.Value), action.Category, "")
+	case definitions.ActionTypeSetContactField:
+		if action.Field != nil {
+			contact.Fields[action.Field.Key] = r.evaluate(action.Value)
+		}
+	case definitions.ActionTypeSetContactName:
+		contact.Name = r.evaluate(action.Name)
+	case definitions.ActionTypeSetContactLanguage:
+		contact.Language = r.evaluate(action.Language)
+	case definitions.ActionTypeAddContactGroups:
+		for _, group := range action.Groups {
+			if group.UUID != "" && !contact.InGroup(group.UUID) {
+				contact.Groups = append(contact.Groups, group.UUID)
+			}
+		}
+	case definitions.ActionTypeRemoveContactGroups:
+		groups := contact.Groups[:0]
+		for _, uuid := range contact.Groups {
+			if !action.AllGroups && !referencesGroup(action.Groups, uuid) {
+				groups = append(groups, uuid)
+			}
+		}
+		contact.Groups = groups
+	case definitions.ActionTypeCallWebhook:
+		status := 200
+		if r.opts.Webhook != nil {
+			call := *action
+			call.URL = r.evaluate(action.URL)
+			call.Body = r.evaluate(action.Body)
+			call.Headers = make(map[string]string, len(action.Headers))
+			for name, value := range action.Headers {
+				call.Headers[name] = r.evaluate(value)
+			}
+			status = r.opts.Webhook(&call)
+		}
+		category := "Success"
+		if status < 200 || status > 299 {
+			category = "Failure"
+		}
+		r.saveResult(node, action.ResultName, strconv.Itoa(status), category, "")
+	}
+}
+
+// route picks the exit of a node, returning nil if the node waits and there are no inputs left
+func (r *runner) route(node *definitions.Node) (*definitions.Exit, error) {
+	router := node.Router
+	if router == nil {
+		if len(node.Exits) == 0 {
+			return &definitions.Exit{}, nil
+		}
+		return &node.Exits[0], nil
+	}
+
+	if router.Wait != nil {
+		if r.session.Inputs == len(r.inputs) {
+			return nil, nil
+		}
+		r.input = r.inputs[r.session.Inputs]
+		r.session.Inputs++
+	}
+
+	var category *definitions.Category
+	operand := r.evaluate(router.Operand)
+	value := operand
+	if router.Type == definitions.RouterTypeRandom {
+		if len(router.Categories) > 0 {
+			category = &router.Categories[r.rand.Intn(len(router.Categories))]
+		}
+	} else {
+		for _, c := range router.Cases {
+			args := r.localizeAll(c.UUID, "arguments", c.Arguments)
+			for i := range args {
+				args[i] = r.evaluate(args[i])
+			}
+			matched, extracted, err := r.test(c.Type, router.Operand, operand, args)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error evaluating case %s of node %s", c.UUID, node.UUID)
+			}
+			if matched {
+				category = router.Category(c.CategoryUUID)
+				if extracted != "" {
+					value = extracted
+				}
+				break
+			}
+		}
+		if category == nil {
+			category = router.Category(router.DefaultCategoryUUID)
+		}
+	}
+	if category == nil {
+		return nil, errors.Errorf("node %s has no category to route to", node.UUID)
+	}
+
+	if router.ResultName != "" {
+		input := ""
+		if router.Wait != nil {
+			input = r.input
+		}
+		r.saveResult(node, router.ResultName, value, r.localize(category.UUID, "name", category.Name), input)
+	}
+
+	exit := node.Exit(category.ExitUUID)
+	if exit == nil {
+		return nil, errors.Errorf("category %s of node %s uses missing exit %s", category.UUID, node.UUID, category.ExitUUID)
+	}
+	return exit, nil
+}
+
+func (r *runner) saveResult(node *definitions.Node, name, value, category, input string) {
+	if name == "" {
+		return
+	}
+	r.session.Results[definitions.ResultKey(name)] = &Result{
+		Name:     name,
+		Value:    value,
+		Category: category,
+		Input:    input,
+		Node:     node.UUID,
+	}
+}
+
+// localize returns the translation of a property in the contact language, if there's one
+func (r *runner) localize(uuid, key, text string) string {
+	if translated := r.flow.Localization[r.session.Contact.Language][uuid][key]; len(translated) > 0 {
+		return translated[0]
+	}
+	return text
+}
+
+// localizeAll returns a copy of the translations of a list property in the contact language, if there are any
+func (r *runner) localizeAll(uuid, key string, texts []string) []string {
+	if translated := r.flow.Localization[r.session.Contact.Language][uuid][key]; len(translated) > 0 {
+		texts = translated
+	}
+	if texts == nil {
+		return nil
+	}
+	return append([]string{}, texts...)
+}
+
+func referencesGroup(groups []definitions.GroupReference, uuid string) bool {
+	for _, group := range groups {
+		if group.UUID == uuid {
+			return true
+		}
+	}
+	return false
+}
+
+// resultReference returns the key of the result an operand refers to, if it's only a result reference
+func resultReference(operand string) (string, bool) {
+	operand = strings.TrimSpace(operand)
+	if !strings.HasPrefix(operand, "@results.") {
+		return "", false
+	}
+	key := strings.TrimPrefix(operand, "@results.")
+	key = strings.TrimSuffix(key, ".value")
+	key = strings.TrimSuffix(key, ".category")
+	return key, !strings.ContainsAny(key, " .")
+}
@@ -0,0 +1,285 @@
+package simulator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rasoro/rapidpro-api-go/v2/definitions"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeFlow(t *testing.T) *definitions.Flow {
+	flow := &definitions.Flow{}
+	assert.NoError(t, json.Unmarshal([]byte(testFlow), flow))
+	return flow
+}
+
+func TestRun(t *testing.T) {
+	flow := decodeFlow(t)
+
+	session, err := Run(flow, []string{"Bob", "ten", "I'm 34"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCompleted, session.Status)
+	assert.Equal(t, 3, session.Inputs)
+	assert.Equal(t, []string{"node-name", "node-age", "node-retry", "node-age", "node-hook", "node-thanks"}, session.Path)
+	assert.Equal(t, []string{
+		"What is your name?",
+		"How old are you, Bob?",
+		"Please send a number",
+		"How old are you, Bob?",
+		"Thanks Bob, you are an Adult",
+	}, messageTexts(session))
+	assert.Equal(t, &Result{Name: "Name", Value: "Bob", Category: "Has Text", Input: "Bob", Node: "node-name"}, session.Result("Name"))
+	assert.Equal(t, "Adult", session.Result("age").Category)
+	assert.Equal(t, "34", session.Result("Age").Value)
+	assert.Equal(t, "I'm 34", session.Result("Age").Input)
+	assert.Equal(t, "Success", session.Result("Webhook").Category)
+	assert.Equal(t, "34", session.Contact.Fields["age"])
+	assert.Equal(t, []string{"group-registered"}, session.Contact.Groups)
+}
+
+func TestRunWaiting(t *testing.T) {
+	session, err := Run(decodeFlow(t), []string{"Bob"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusWaiting, session.Status)
+	assert.Equal(t, []string{"node-name", "node-age"}, session.Path)
+	assert.Nil(t, session.Result("age"))
+}
+
+func TestRunWithOptions(t *testing.T) {
+	contact := &Contact{Name: "Ana", Language: "spa", Groups: []string{"group-registered"}}
+	opts := &Options{
+		Contact: contact,
+		Webhook: func(action *definitions.Action) int {
+			assert.Equal(t, "https://example.com/register?age=12", action.URL)
+			return 500
+		},
+	}
+
+	session, err := Run(decodeFlow(t), []string{"Ana", "12"}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCompleted, session.Status)
+	assert.Equal(t, []string{"node-name", "node-age", "node-hook", "node-failed"}, session.Path)
+	assert.Equal(t, "¿Cuántos años tienes, Ana?", session.Messages[1].Text)
+	assert.Equal(t, "Menor", session.Result("age").Category)
+	assert.Equal(t, "Failure", session.Result("webhook").Category)
+	assert.NotSame(t, contact, session.Contact)
+
+	// the flow changes the session contact but not the one passed in
+	assert.Equal(t, "12", session.Contact.Fields["age"])
+	assert.Equal(t, &Contact{Name: "Ana", Language: "spa", Groups: []string{"group-registered"}}, contact)
+}
+
+func TestRunReusingContact(t *testing.T) {
+	contact := &Contact{Name: "Ana", Fields: map[string]string{"age": "30"}, Groups: []string{"group-registered", "group-other"}}
+	flow := decodeFlow(t)
+	flow.Nodes[4].Actions[0].Type = "remove_contact_groups"
+
+	for _, inputs := range [][]string{{"Bob", "40"}, {"Ana", "50"}} {
+		session, err := Run(flow, inputs, &Options{Contact: contact})
+		assert.NoError(t, err)
+		assert.Equal(t, inputs[0], session.Contact.Name)
+		assert.Equal(t, []string{"group-other"}, session.Contact.Groups)
+	}
+	assert.Equal(t, &Contact{Name: "Ana", Fields: map[string]string{"age": "30"}, Groups: []string{"group-registered", "group-other"}}, contact)
+}
+
+func TestRunMaxSteps(t *testing.T) {
+	inputs := make([]string, 20)
+	for i := range inputs {
+		inputs[i] = "none"
+	}
+	session, err := Run(decodeFlow(t), append([]string{"Bob"}, inputs...), &Options{MaxSteps: 5})
+	assert.Equal(t, ErrMaxSteps, err)
+	assert.Equal(t, 5, len(session.Path))
+}
+
+func TestCaseTests(t *testing.T) {
+	r := &runner{session: &Session{
+		Contact: &Contact{Groups: []string{"group-1"}},
+		Results: map[string]*Result{"color": {Name: "Color", Value: "red", Category: "Red"}},
+	}}
+
+	tcs := []struct {
+		caseType string
+		operand  string
+		args     []string
+		matched  bool
+	}{
+		{"has_text", "  ", nil, false},
+		{"has_text", "hi", nil, true},
+		{"has_only_text", "Yes", []string{"Yes"}, true},
+		{"has_only_text", "yes", []string{"Yes"}, false},
+		{"has_beginning", " Yes please", []string{"yes"}, true},
+		{"has_any_word", "I like RED apples", []string{"blue red"}, true},
+		{"has_any_word", "I like reddish apples", []string{"red"}, false},
+		{"has_all_words", "red and blue", []string{"Blue red"}, true},
+		{"has_all_words", "red", []string{"red blue"}, false},
+		{"has_phrase", "I want to stop now", []string{"to stop"}, true},
+		{"has_phrase", "stop to", []string{"to stop"}, false},
+		{"has_only_phrase", "Stop, now!", []string{"stop now"}, true},
+		{"has_pattern", "ABC-123", []string{`^abc-\d+$`}, true},
+		{"has_email", "mail me at bob@example.com", nil, true},
+		{"has_phone", "+1 (206) 555-0100", nil, true},
+		{"has_phone", "call me", nil, false},
+		{"has_number", "about 12.5 kg", nil, true},
+		{"has_number", "twelve", nil, false},
+		{"has_number_between", "I'm 34", []string{"18", "120"}, true},
+		{"has_number_between", "12", []string{"18", "120"}, false},
+		{"has_number_lt", "12", []string{"18"}, true},
+		{"has_number_lte", "18", []string{"18"}, true},
+		{"has_number_gt", "12", []string{"18"}, false},
+		{"has_number_gte", "-3", []string{"-3"}, true},
+		{"has_number_eq", "7", []string{"7.0"}, true},
+		{"has_group", "", []string{"group-1", "Group 1"}, true},
+		{"has_group", "", []string{"group-2", "Group 2"}, false},
+		{"has_wait_timed_out", "", nil, false},
+	}
+	for _, tc := range tcs {
+		matched, _, err := r.test(tc.caseType, "", tc.operand, tc.args)
+		assert.NoError(t, err, tc.caseType)
+		assert.Equal(t, tc.matched, matched, "%s(%q, %v)", tc.caseType, tc.operand, tc.args)
+	}
+
+	matched, _, err := r.test("has_category", "@results.color", "red", []string{"Blue", "red"})
+	assert.NoError(t, err)
+	assert.True(t, matched)
+
+	matched, extracted, err := r.test("has_number_between", "", "I'm 34", []string{"18", "120"})
+	assert.NoError(t, err)
+	assert.True(t, matched)
+	assert.Equal(t, "34", extracted)
+
+	_, _, err = r.test("has_category", "@input", "red", []string{"Red"})
+	assert.EqualError(t, err, "has_category must test a result")
+
+	_, _, err = r.test("has_number_between", "", "5", []string{"1"})
+	assert.EqualError(t, err, "has_number_between is missing arguments")
+
+	_, _, err = r.test("has_date", "", "today", nil)
+	assert.EqualError(t, err, "unsupported case type has_date")
+}
+
+func TestEvaluate(t *testing.T) {
+	r := &runner{
+		input: "hello",
+		session: &Session{
+			Contact: &Contact{Name: "Bob", Language: "eng", URN: "tel:+1206", Fields: map[string]string{"age": "34"}},
+			Results: map[string]*Result{"color": {Value: "red", Category: "Red", Input: "Red!"}},
+		},
+	}
+
+	assert.Equal(t, "hello hello Bob Bob eng tel:+1206 34 34", r.evaluate("@input @input.text @contact @contact.name @contact.language @contact.urn @contact.fields.age @fields.age"))
+	assert.Equal(t, "red red Red Red! ", r.evaluate("@results.color @results.color.value @results.color.category @results.color.input @results.other"))
+	assert.Equal(t, "bob@example.com @webhook.json @ 34.", r.evaluate("bob@@example.com @webhook.json @@ @fields.age."))
+}
+
+func messageTexts(session *Session) []string {
+	texts := make([]string, len(session.Messages))
+	for i, msg := range session.Messages {
+		texts[i] = msg.Text
+	}
+	return texts
+}
+
+var testFlow = `{
+	"uuid": "flow-1",
+	"name": "Registration",
+	"language": "eng",
+	"type": "messaging",
+	"localization": {
+		"spa": {
+			"action-age": {"text": ["¿Cuántos años tienes, @contact.name?"]},
+			"category-minor": {"name": ["Menor"]}
+		}
+	},
+	"nodes": [
+		{
+			"uuid": "node-name",
+			"actions": [{"uuid": "action-name", "type": "send_msg", "text": "What is your name?"}],
+			"router": {
+				"type": "switch",
+				"operand": "@input.text",
+				"result_name": "Name",
+				"wait": {"type": "msg"},
+				"cases": [{"uuid": "case-text", "type": "has_text", "category_uuid": "category-text"}],
+				"categories": [
+					{"uuid": "category-text", "name": "Has Text", "exit_uuid": "exit-text"},
+					{"uuid": "category-name-other", "name": "Other", "exit_uuid": "exit-name-other"}
+				],
+				"default_category_uuid": "category-name-other"
+			},
+			"exits": [
+				{"uuid": "exit-text", "destination_uuid": "node-age"},
+				{"uuid": "exit-name-other", "destination_uuid": "node-name"}
+			]
+		},
+		{
+			"uuid": "node-age",
+			"actions": [
+				{"uuid": "action-contact-name", "type": "set_contact_name", "name": "@results.name"},
+				{"uuid": "action-age", "type": "send_msg", "text": "How old are you, @contact.name?"}
+			],
+			"router": {
+				"type": "switch",
+				"operand": "@input.text",
+				"result_name": "Age",
+				"wait": {"type": "msg"},
+				"cases": [
+					{"uuid": "case-adult", "type": "has_number_between", "arguments": ["18", "120"], "category_uuid": "category-adult"},
+					{"uuid": "case-minor", "type": "has_number_lt", "arguments": ["18"], "category_uuid": "category-minor"}
+				],
+				"categories": [
+					{"uuid": "category-adult", "name": "Adult", "exit_uuid": "exit-adult"},
+					{"uuid": "category-minor", "name": "Minor", "exit_uuid": "exit-minor"},
+					{"uuid": "category-age-other", "name": "Other", "exit_uuid": "exit-age-other"}
+				],
+				"default_category_uuid": "category-age-other"
+			},
+			"exits": [
+				{"uuid": "exit-adult", "destination_uuid": "node-hook"},
+				{"uuid": "exit-minor", "destination_uuid": "node-hook"},
+				{"uuid": "exit-age-other", "destination_uuid": "node-retry"}
+			]
+		},
+		{
+			"uuid": "node-retry",
+			"actions": [{"uuid": "action-retry", "type": "send_msg", "text": "Please send a number"}],
+			"exits": [{"uuid": "exit-retry", "destination_uuid": "node-age"}]
+		},
+		{
+			"uuid": "node-hook",
+			"actions": [
+				{"uuid": "action-field", "type": "set_contact_field", "field": {"key": "age", "name": "Age"}, "value": "@results.age"},
+				{"uuid": "action-hook", "type": "call_webhook", "method": "GET", "url": "https://example.com/register?age=@fields.age", "result_name": "Webhook"}
+			],
+			"router": {
+				"type": "switch",
+				"operand": "@results.webhook.category",
+				"cases": [{"uuid": "case-success", "type": "has_only_text", "arguments": ["Success"], "category_uuid": "category-success"}],
+				"categories": [
+					{"uuid": "category-success", "name": "Success", "exit_uuid": "exit-success"},
+					{"uuid": "category-failure", "name": "Failure", "exit_uuid": "exit-failure"}
+				],
+				"default_category_uuid": "category-failure"
+			},
+			"exits": [
+				{"uuid": "exit-success", "destination_uuid": "node-thanks"},
+				{"uuid": "exit-failure", "destination_uuid": "node-failed"}
+			]
+		},
+		{
+			"uuid": "node-thanks",
+			"actions": [
+				{"uuid": "action-group", "type": "add_contact_groups", "groups": [{"uuid": "group-registered", "name": "Registered"}]},
+				{"uuid": "action-thanks", "type": "send_msg", "text": "Thanks @contact.name, you are an @results.age.category"}
+			],
+			"exits": [{"uuid": "exit-thanks"}]
+		},
+		{
+			"uuid": "node-failed",
+			"actions": [{"uuid": "action-failed", "type": "send_msg", "text": "Something went wrong"}],
+			"exits": [{"uuid": "exit-failed"}]
+		}
+	]
+}`
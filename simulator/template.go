@@ -0,0 +1,55 @@
+package simulator
+
+import (
+	"regexp"
+	"strings"
+)
+
+// expressionRegex matches the expressions the simulator can evaluate, others are left as they are
+var expressionRegex = regexp.MustCompile(`@@|@(?:input(?:\.text)?|contact(?:\.(?:name|language|urn|fields\.\w+))?|fields\.\w+|results\.\w+(?:\.(?:value|category|input))?)`)
+
+// evaluate replaces the expressions in a template with their values in the session
+func (r *runner) evaluate(template string) string {
+	return expressionRegex.ReplaceAllStringFunc(template, func(expression string) string {
+		if expression == "@@" {
+			return "@"
+		}
+
+		contact := r.session.Contact
+		parts := strings.Split(strings.TrimPrefix(expression, "@"), ".")
+		switch parts[0] {
+		case "input":
+			return r.input
+		case "fields":
+			return contact.Fields[parts[1]]
+		case "contact":
+			if len(parts) == 1 {
+				return contact.Name
+			}
+			switch parts[1] {
+			case "name":
+				return contact.Name
+			case "language":
+				return contact.Language
+			case "urn":
+				return contact.URN
+			}
+			return contact.Fields[parts[2]]
+		case "results":
+			result := r.session.Results[parts[1]]
+			if result == nil {
+				return ""
+			}
+			if len(parts) == 3 {
+				switch parts[2] {
+				case "category":
+					return result.Category
+				case "input":
+					return result.Input
+				}
+			}
+			return result.Value
+		}
+		return expression
+	})
+}
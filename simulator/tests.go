@@ -0,0 +1,153 @@
+package simulator
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+var numberRegex = regexp.MustCompile(`-?\d+(?:\.\d+)?`)
+var emailRegex = regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.-]+`)
+var phoneRegex = regexp.MustCompile(`^\+?[\d\s().-]{7,}$`)
+
+// test evaluates a router case against the operand, returning the part of the operand that matched
+// when it's not all of it, like the number found by numeric tests
+func (r *runner) test(caseType, rawOperand, operand string, args []string) (bool, string, error) {
+	arg := func(i int) string {
+		if i < len(args) {
+			return args[i]
+		}
+		return ""
+	}
+
+	switch caseType {
+	case "has_text":
+		return strings.TrimSpace(operand) != "", "", nil
+	case "has_value":
+		return operand != "", "", nil
+	case "has_only_text":
+		return operand == arg(0), "", nil
+	case "has_beginning":
+		return strings.HasPrefix(strings.ToLower(strings.TrimSpace(operand)), strings.ToLower(strings.TrimSpace(arg(0)))), "", nil
+	case "has_any_word":
+		words := tokenize(operand)
+		for _, word := range tokenize(arg(0)) {
+			if containsWord(words, word) {
+				return true, "", nil
+			}
+		}
+		return false, "", nil
+	case "has_all_words":
+		words, wanted := tokenize(operand), tokenize(arg(0))
+		for _, word := range wanted {
+			if !containsWord(words, word) {
+				return false, "", nil
+			}
+		}
+		return len(wanted) > 0, "", nil
+	case "has_phrase":
+		return containsPhrase(tokenize(operand), tokenize(arg(0))), "", nil
+	case "has_only_phrase":
+		return strings.Join(tokenize(operand), " ") == strings.Join(tokenize(arg(0)), " "), "", nil
+	case "has_pattern":
+		pattern, err := regexp.Compile("(?i)" + arg(0))
+		if err != nil {
+			return false, "", errors.Wrapf(err, "invalid pattern %q", arg(0))
+		}
+		return pattern.MatchString(operand), "", nil
+	case "has_email":
+		return emailRegex.MatchString(operand), "", nil
+	case "has_phone":
+		return phoneRegex.MatchString(strings.TrimSpace(operand)), "", nil
+	case "has_number", "has_number_eq", "has_number_lt", "has_number_lte", "has_number_gt", "has_number_gte", "has_number_between":
+		return testNumber(caseType, operand, args)
+	case "has_category":
+		key, ok := resultReference(rawOperand)
+		if !ok {
+			return false, "", errors.Errorf("%s must test a result", caseType)
+		}
+		result := r.session.Results[key]
+		if result == nil {
+			return false, "", nil
+		}
+		for _, category := range args {
+			if strings.EqualFold(category, result.Category) {
+				return true, "", nil
+			}
+		}
+		return false, "", nil
+	case "has_group":
+		return r.session.Contact.InGroup(arg(0)), "", nil
+	case "has_wait_timed_out":
+		return false, "", nil
+	}
+	return false, "", errors.Errorf("unsupported case type %s", caseType)
+}
+
+func testNumber(caseType, operand string, args []string) (bool, string, error) {
+	match := numberRegex.FindString(operand)
+	if match == "" {
+		return false, "", nil
+	}
+	number, _ := strconv.ParseFloat(match, 64)
+	if caseType == "has_number" {
+		return true, match, nil
+	}
+
+	bounds := make([]float64, len(args))
+	for i, arg := range args {
+		bound, err := strconv.ParseFloat(strings.TrimSpace(arg), 64)
+		if err != nil {
+			return false, "", errors.Errorf("%s argument %q isn't a number", caseType, arg)
+		}
+		bounds[i] = bound
+	}
+	if len(bounds) == 0 || caseType == "has_number_between" && len(bounds) < 2 {
+		return false, "", errors.Errorf("%s is missing arguments", caseType)
+	}
+
+	switch caseType {
+	case "has_number_eq":
+		return number == bounds[0], match, nil
+	case "has_number_lt":
+		return number < bounds[0], match, nil
+	case "has_number_lte":
+		return number <= bounds[0], match, nil
+	case "has_number_gt":
+		return number > bounds[0], match, nil
+	case "has_number_gte":
+		return number >= bounds[0], match, nil
+	}
+	return number >= bounds[0] && number <= bounds[1], match, nil
+}
+
+// tokenize splits text into lowercase words
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func containsWord(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPhrase(words, phrase []string) bool {
+	if len(phrase) == 0 {
+		return true
+	}
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		if strings.Join(words[i:i+len(phrase)], " ") == strings.Join(phrase, " ") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package syncer
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/rasoro/rapidpro-api-go/v2/flows"
+	"github.com/rasoro/rapidpro-api-go/v2/flowstarts"
+	"github.com/rasoro/rapidpro-api-go/v2/messages"
+)
+
+// Endpoint names of the sources
+const (
+	EndpointFlows      = "flows"
+	EndpointFlowStarts = "flow_starts"
+	EndpointMessages   = "messages"
+)
+
+type flowsSource struct {
+	service *flows.ApiService
+}
+
+// FlowsSource returns a Source of flows, records are keyed by uuid and have *flows.Flow values
+func FlowsSource(service *flows.ApiService) Source {
+	return &flowsSource{service: service}
+}
+
+func (s *flowsSource) Endpoint() string { return EndpointFlows }
+
+func (s *flowsSource) Fetch(ctx context.Context, after *time.Time, cursor string) (*Page, error) {
+	resp, err := s.service.Get(&flows.QueryParams{After: after, Cursor: cursor})
+	if err != nil {
+		return nil, err
+	}
+	page := &Page{NextCursor: resp.NextCursor()}
+	for i := range resp.Results {
+		flow := &resp.Results[i]
+		page.Records = append(page.Records, Record{Key: flow.UUID, ModifiedOn: flow.ModifiedOn, Value: flow})
+	}
+	return page, nil
+}
+
+type flowStartsSource struct {
+	service *flowstarts.ApiService
+}
+
+// FlowStartsSource returns a Source of flow starts, records are keyed by uuid and have *flowstarts.FlowStart values
+func FlowStartsSource(service *flowstarts.ApiService) Source {
+	return &flowStartsSource{service: service}
+}
+
+func (s *flowStartsSource) Endpoint() string { return EndpointFlowStarts }
+
+func (s *flowStartsSource) Fetch(ctx context.Context, after *time.Time, cursor string) (*Page, error) {
+	resp, err := s.service.Get(&flowstarts.QueryParams{After: after, Cursor: cursor})
+	if err != nil {
+		return nil, err
+	}
+	page := &Page{NextCursor: resp.NextCursor()}
+	for i := range resp.Results {
+		start := &resp.Results[i]
+		page.Records = append(page.Records, Record{Key: start.UUID, ModifiedOn: modifiedOn(start.ModifiedOn, start.CreatedOn), Value: start})
+	}
+	return page, nil
+}
+
+type messagesSource struct {
+	service *messages.ApiService
+}
+
+// MessagesSource returns a Source of messages, records are keyed by id and have *messages.Message values.
+// The messages endpoint filters on created_on, so only new messages are synced and changes to messages
+// already synced, e.g. of their status, are not.
+func MessagesSource(service *messages.ApiService) Source {
+	return &messagesSource{service: service}
+}
+
+func (s *messagesSource) Endpoint() string { return EndpointMessages }
+
+func (s *messagesSource) Fetch(ctx context.Context, after *time.Time, cursor string) (*Page, error) {
+	resp, err := s.service.Get(&messages.QueryParams{After: after, Cursor: cursor})
+	if err != nil {
+		return nil, err
+	}
+	page := &Page{NextCursor: resp.NextCursor()}
+	for i := range resp.Results {
+		msg := &resp.Results[i]
+		page.Records = append(page.Records, Record{
+			Key:        strconv.Itoa(msg.ID),
+			ModifiedOn: modifiedOn(msg.ModifiedOn, msg.CreatedOn),
+			Watermark:  modifiedOn(msg.CreatedOn, msg.ModifiedOn),
+			Value:      msg,
+		})
+	}
+	return page, nil
+}
+
+// modifiedOn returns the modification time of a record, falling back to its creation time
+func modifiedOn(modified, created *time.Time) time.Time {
+	if modified != nil {
+		return *modified
+	}
+	if created != nil {
+		return *created
+	}
+	return time.Time{}
+}
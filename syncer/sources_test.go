@@ -0,0 +1,54 @@
+package syncer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/rasoro/rapidpro-api-go/v2/flows"
+	"github.com/rasoro/rapidpro-api-go/v2/flowstarts"
+	"github.com/rasoro/rapidpro-api-go/v2/messages"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSources(t *testing.T) {
+	after := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "2022-03-01T10:00:00Z", r.URL.Query().Get("after"))
+			assert.Equal(t, "cD0x", r.URL.Query().Get("cursor"))
+			switch r.URL.Path {
+			case "/v2/flows.json":
+				w.Write([]byte(`{"next": null, "results": [{"uuid": "f5901b62-ba76-4003-9c62-72fdacc1b7b7", "modified_on": "2022-03-01T10:00:01.5Z"}]}`))
+			case "/v2/flow_starts.json":
+				w.Write([]byte(`{"next": null, "results": [{"uuid": "09d23a05-47fe-11e4-bfe9-b8f6b119e9ab", "created_on": "2022-03-01T10:00:02Z"}]}`))
+			case "/v2/messages.json":
+				w.Write([]byte(`{"next": "https://rapidpro.io/api/v2/messages.json?cursor=cD0y", "results": [{"id": 4105426, "created_on": "2022-03-01T10:00:03Z", "modified_on": "2022-03-01T10:00:04Z"}]}`))
+			}
+		}))
+	defer mockServer.Close()
+
+	handler := client.NewRequestHandler(&client.Client{Credentials: &client.Credentials{Token: "token123"}})
+	ctx := context.Background()
+
+	page, err := FlowsSource(flows.NewService(handler, mockServer.URL)).Fetch(ctx, &after, "cD0x")
+	assert.NoError(t, err)
+	assert.Equal(t, "f5901b62-ba76-4003-9c62-72fdacc1b7b7", page.Records[0].Key)
+	assert.Equal(t, time.Date(2022, 3, 1, 10, 0, 1, 500000000, time.UTC), page.Records[0].ModifiedOn)
+	assert.IsType(t, &flows.Flow{}, page.Records[0].Value)
+
+	page, err = FlowStartsSource(flowstarts.NewService(handler, mockServer.URL)).Fetch(ctx, &after, "cD0x")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 3, 1, 10, 0, 2, 0, time.UTC), page.Records[0].ModifiedOn)
+
+	page, err = MessagesSource(messages.NewService(handler, mockServer.URL)).Fetch(ctx, &after, "cD0x")
+	assert.NoError(t, err)
+	assert.Equal(t, "4105426", page.Records[0].Key)
+	assert.Equal(t, time.Date(2022, 3, 1, 10, 0, 4, 0, time.UTC), page.Records[0].ModifiedOn)
+	assert.Equal(t, time.Date(2022, 3, 1, 10, 0, 3, 0, time.UTC), page.Records[0].Watermark)
+	assert.Equal(t, "cD0y", page.NextCursor)
+	assert.IsType(t, &messages.Message{}, page.Records[0].Value)
+}
@@ -0,0 +1,106 @@
+package syncer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CheckpointStore persists the checkpoints of endpoints
+type CheckpointStore interface {
+	// Load returns the checkpoint of an endpoint, or nil if it was never synced
+	Load(endpoint string) (*Checkpoint, error)
+	Save(endpoint string, checkpoint *Checkpoint) error
+}
+
+// MemoryStore is a CheckpointStore that keeps checkpoints in memory
+type MemoryStore struct {
+	mutex       sync.Mutex
+	checkpoints map[string][]byte
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{checkpoints: make(map[string][]byte)}
+}
+
+// Load returns a copy of the checkpoint of an endpoint
+func (s *MemoryStore) Load(endpoint string) (*Checkpoint, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, ok := s.checkpoints[endpoint]
+	if !ok {
+		return nil, nil
+	}
+	checkpoint := &Checkpoint{}
+	return checkpoint, json.Unmarshal(data, checkpoint)
+}
+
+// Save stores a copy of the checkpoint of an endpoint
+func (s *MemoryStore) Save(endpoint string, checkpoint *Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.checkpoints[endpoint] = data
+	return nil
+}
+
+// FileStore is a CheckpointStore that keeps the checkpoint of each endpoint in a JSON file of a directory
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a FileStore that keeps checkpoints in dir, which is created if it doesn't exist
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) path(endpoint string) string {
+	return filepath.Join(s.dir, endpoint+".json")
+}
+
+// Load reads the checkpoint file of an endpoint
+func (s *FileStore) Load(endpoint string) (*Checkpoint, error) {
+	data, err := ioutil.ReadFile(s.path(endpoint))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := &Checkpoint{}
+	return checkpoint, json.Unmarshal(data, checkpoint)
+}
+
+// Save writes the checkpoint file of an endpoint, replacing the previous one atomically
+func (s *FileStore) Save(endpoint string, checkpoint *Checkpoint) error {
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(s.dir, endpoint+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(endpoint))
+}
@@ -0,0 +1,46 @@
+package syncer
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testStore(t *testing.T, store CheckpointStore) {
+	checkpoint, err := store.Load("messages")
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	saved := &Checkpoint{
+		After:    time.Date(2022, 3, 1, 10, 0, 2, 500000, time.UTC),
+		Boundary: map[string]time.Time{"123": time.Date(2022, 3, 1, 10, 0, 2, 500000, time.UTC)},
+		Cursor:   "cD0yMDE2",
+	}
+	assert.NoError(t, store.Save("messages", saved))
+
+	checkpoint, err = store.Load("messages")
+	assert.NoError(t, err)
+	assert.Equal(t, saved, checkpoint)
+	assert.NotSame(t, saved, checkpoint)
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoints")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := NewFileStore(dir + "/sync")
+	assert.NoError(t, err)
+	testStore(t, store)
+
+	files, _ := ioutil.ReadDir(dir + "/sync")
+	assert.Equal(t, 1, len(files))
+	assert.Equal(t, "messages.json", files[0].Name())
+}
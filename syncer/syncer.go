@@ -0,0 +1,188 @@
+// Package syncer mirrors endpoints incrementally by polling them for records modified, or created for
+// endpoints that filter on creation, after a high-water mark that is persisted between runs.
+package syncer
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Record is a record of an endpoint along with what's needed to track its changes
+type Record struct {
+	// Key identifies the record within its endpoint
+	Key        string
+	ModifiedOn time.Time
+	// Watermark is the time the endpoint filters the record on with after when that isn't its modification
+	// time, e.g. the creation time of messages. ModifiedOn is used when it's zero.
+	Watermark time.Time
+	// Value is the record as returned by its service, e.g. a *flows.Flow
+	Value interface{}
+}
+
+// Page is a page of records fetched from an endpoint
+type Page struct {
+	Records []Record
+	// NextCursor is the cursor of the next page, empty on the last page
+	NextCursor string
+}
+
+// watermark returns the time the endpoint filters the record on
+func (r *Record) watermark() time.Time {
+	if !r.Watermark.IsZero() {
+		return r.Watermark
+	}
+	return r.ModifiedOn
+}
+
+// Source fetches the records of an endpoint with a watermark on or after a time
+type Source interface {
+	// Endpoint is the name checkpoints of the source are stored under
+	Endpoint() string
+	// Fetch returns a page of the records with a watermark on or after after, which is nil on the first sync
+	Fetch(ctx context.Context, after *time.Time, cursor string) (*Page, error)
+}
+
+// Handler is called with the new and changed records of each page. A checkpoint is saved after it returns,
+// so records it handled before the process stops without saving it are delivered again after a restart.
+type Handler func(endpoint string, records []Record) error
+
+// Checkpoint is the sync state of an endpoint
+type Checkpoint struct {
+	// After is the high-water mark, the latest watermark of the records synced in complete passes
+	After time.Time `json:"after"`
+	// Boundary are the watermarks of the records synced with a watermark in the same second as After
+	// by their key. The endpoints filter on whole seconds, so these are returned again and
+	// are skipped unless they changed.
+	Boundary map[string]time.Time `json:"boundary,omitempty"`
+
+	// Cursor, Next and NextBoundary track a pass that was interrupted, so it can be resumed
+	Cursor       string               `json:"cursor,omitempty"`
+	Next         time.Time            `json:"next,omitempty"`
+	NextBoundary map[string]time.Time `json:"next_boundary,omitempty"`
+}
+
+// seen returns whether a record was already synced
+func (c *Checkpoint) seen(record *Record) bool {
+	watermark, ok := c.Boundary[record.Key]
+	return ok && watermark.Equal(record.watermark())
+}
+
+// track records that a record was synced in the current pass
+func (c *Checkpoint) track(record *Record) {
+	watermark := record.watermark()
+	if watermark.After(c.Next) {
+		c.Next = watermark
+	}
+	if c.NextBoundary == nil {
+		c.NextBoundary = make(map[string]time.Time)
+	}
+	c.NextBoundary[record.Key] = watermark
+	c.NextBoundary = boundary(c.NextBoundary, c.Next)
+}
+
+// complete moves the high-water mark to the latest record of the finished pass
+func (c *Checkpoint) complete() {
+	if c.Next.After(c.After) {
+		c.After = c.Next
+	}
+	merged := make(map[string]time.Time, len(c.Boundary)+len(c.NextBoundary))
+	for key, watermark := range c.Boundary {
+		merged[key] = watermark
+	}
+	for key, watermark := range c.NextBoundary {
+		merged[key] = watermark
+	}
+	c.Boundary = boundary(merged, c.After)
+	c.Cursor, c.Next, c.NextBoundary = "", time.Time{}, nil
+}
+
+// boundary returns the records with a watermark in the same second as the high-water mark, up to it
+func boundary(records map[string]time.Time, highWater time.Time) map[string]time.Time {
+	start := highWater.Truncate(time.Second)
+	for key, watermark := range records {
+		if watermark.Before(start) || watermark.After(highWater) {
+			delete(records, key)
+		}
+	}
+	return records
+}
+
+// Engine syncs sources, handing their changes to a handler and persisting checkpoints in a store
+type Engine struct {
+	store   CheckpointStore
+	handler Handler
+	sources []Source
+}
+
+// NewEngine returns an Engine that syncs the given sources
+func NewEngine(store CheckpointStore, handler Handler, sources ...Source) *Engine {
+	return &Engine{store: store, handler: handler, sources: sources}
+}
+
+// Sync syncs every source in order, stopping at the first error
+func (e *Engine) Sync(ctx context.Context) error {
+	for _, source := range e.sources {
+		if _, err := e.SyncSource(ctx, source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SyncSource pages through the changes of a source since its checkpoint, or resumes the pass that
+// was interrupted, and returns the number of records handed to the handler
+func (e *Engine) SyncSource(ctx context.Context, source Source) (int, error) {
+	endpoint := source.Endpoint()
+	checkpoint, err := e.store.Load(endpoint)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error loading checkpoint of %s", endpoint)
+	}
+	if checkpoint == nil {
+		checkpoint = &Checkpoint{}
+	}
+
+	synced := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return synced, err
+		}
+
+		var after *time.Time
+		if !checkpoint.After.IsZero() {
+			after = &checkpoint.After
+		}
+		page, err := source.Fetch(ctx, after, checkpoint.Cursor)
+		if err != nil {
+			return synced, errors.Wrapf(err, "error fetching %s", endpoint)
+		}
+
+		records := make([]Record, 0, len(page.Records))
+		for i := range page.Records {
+			record := &page.Records[i]
+			if checkpoint.seen(record) {
+				continue
+			}
+			records = append(records, *record)
+			checkpoint.track(record)
+		}
+		if len(records) > 0 {
+			if err := e.handler(endpoint, records); err != nil {
+				return synced, errors.Wrapf(err, "error handling %s", endpoint)
+			}
+			synced += len(records)
+		}
+
+		checkpoint.Cursor = page.NextCursor
+		if checkpoint.Cursor == "" {
+			checkpoint.complete()
+		}
+		if err := e.store.Save(endpoint, checkpoint); err != nil {
+			return synced, errors.Wrapf(err, "error saving checkpoint of %s", endpoint)
+		}
+		if checkpoint.Cursor == "" {
+			return synced, nil
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package syncer
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSource serves records like the endpoints do, newest first and filtering on whole seconds. It filters
+// on the creation times of records when they're set, like the messages endpoint.
+type fakeSource struct {
+	records  map[string]time.Time
+	created  map[string]time.Time
+	pageSize int
+	fetches  int
+}
+
+func (s *fakeSource) Endpoint() string { return "fake" }
+
+func (s *fakeSource) Fetch(ctx context.Context, after *time.Time, cursor string) (*Page, error) {
+	s.fetches++
+	var records []Record
+	for key, modifiedOn := range s.records {
+		record := Record{Key: key, ModifiedOn: modifiedOn, Watermark: s.created[key], Value: key}
+		if after == nil || !record.watermark().Before(after.Truncate(time.Second)) {
+			records = append(records, record)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].watermark().Equal(records[j].watermark()) {
+			return records[i].Key < records[j].Key
+		}
+		return records[i].watermark().After(records[j].watermark())
+	})
+
+	offset, _ := strconv.Atoi(cursor)
+	end := offset + s.pageSize
+	page := &Page{}
+	if end < len(records) {
+		page.NextCursor = strconv.Itoa(end)
+	} else {
+		end = len(records)
+	}
+	page.Records = records[offset:end]
+	return page, nil
+}
+
+type recorder struct {
+	keys []string
+	fail func(records []Record) bool
+}
+
+func (r *recorder) handle(endpoint string, records []Record) error {
+	if r.fail != nil && r.fail(records) {
+		return errors.New("boom")
+	}
+	for _, record := range records {
+		r.keys = append(r.keys, record.Key)
+	}
+	return nil
+}
+
+func (r *recorder) take() []string {
+	keys := r.keys
+	r.keys = nil
+	sort.Strings(keys)
+	return keys
+}
+
+var base = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func at(seconds int, micros int) time.Time {
+	return base.Add(time.Duration(seconds)*time.Second + time.Duration(micros)*time.Microsecond)
+}
+
+func TestSyncSource(t *testing.T) {
+	source := &fakeSource{pageSize: 2, records: map[string]time.Time{
+		"a": at(0, 0),
+		"b": at(1, 0),
+		"c": at(2, 100),
+		"d": at(2, 500),
+		"e": at(2, 500),
+	}}
+	store := NewMemoryStore()
+	r := &recorder{}
+	engine := NewEngine(store, r.handle, source)
+	ctx := context.Background()
+
+	n, err := engine.SyncSource(ctx, source)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, r.take())
+	assert.Equal(t, 3, source.fetches)
+
+	checkpoint, _ := store.Load("fake")
+	assert.Equal(t, at(2, 500), checkpoint.After)
+	assert.Equal(t, map[string]time.Time{"c": at(2, 100), "d": at(2, 500), "e": at(2, 500)}, checkpoint.Boundary)
+	assert.Equal(t, "", checkpoint.Cursor)
+
+	// nothing changed, the records in the boundary second are returned again but skipped
+	n, err = engine.SyncSource(ctx, source)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Empty(t, r.take())
+
+	// a record modified at exactly the high-water mark and one that became visible late in the same second
+	source.records["f"] = at(2, 500)
+	source.records["g"] = at(2, 50)
+	n, err = engine.SyncSource(ctx, source)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"f", "g"}, r.take())
+
+	checkpoint, _ = store.Load("fake")
+	assert.Equal(t, at(2, 500), checkpoint.After)
+	assert.Equal(t, 5, len(checkpoint.Boundary))
+
+	// changed records move the high-water mark and the boundary
+	source.records["a"] = at(5, 0)
+	source.records["c"] = at(4, 0)
+	n, err = engine.SyncSource(ctx, source)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"a", "c"}, r.take())
+
+	checkpoint, _ = store.Load("fake")
+	assert.Equal(t, at(5, 0), checkpoint.After)
+	assert.Equal(t, map[string]time.Time{"a": at(5, 0)}, checkpoint.Boundary)
+}
+
+func TestSyncSourceFilteredOnCreation(t *testing.T) {
+	source := &fakeSource{
+		pageSize: 2,
+		records:  map[string]time.Time{"a": at(10, 0), "b": at(1, 0)},
+		created:  map[string]time.Time{"a": at(0, 0), "b": at(1, 0)},
+	}
+	store := NewMemoryStore()
+	r := &recorder{}
+	engine := NewEngine(store, r.handle, source)
+	ctx := context.Background()
+
+	n, err := engine.SyncSource(ctx, source)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"a", "b"}, r.take())
+
+	// the high-water mark is the latest creation time, not the later modification of a
+	checkpoint, _ := store.Load("fake")
+	assert.Equal(t, at(1, 0), checkpoint.After)
+	assert.Equal(t, map[string]time.Time{"b": at(1, 0)}, checkpoint.Boundary)
+
+	// so a record created after b but before a was modified isn't skipped
+	source.records["c"] = at(2, 0)
+	source.created["c"] = at(2, 0)
+	n, err = engine.SyncSource(ctx, source)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"c"}, r.take())
+}
+
+func TestSyncSourceResume(t *testing.T) {
+	source := &fakeSource{pageSize: 2, records: map[string]time.Time{
+		"a": at(0, 0),
+		"b": at(1, 0),
+		"c": at(2, 0),
+		"d": at(3, 0),
+		"e": at(4, 0),
+	}}
+	store := NewMemoryStore()
+	r := &recorder{fail: func(records []Record) bool { return records[0].Key == "c" }}
+	engine := NewEngine(store, r.handle, source)
+	ctx := context.Background()
+
+	n, err := engine.SyncSource(ctx, source)
+	assert.EqualError(t, err, "error handling fake: boom")
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"d", "e"}, r.take())
+
+	checkpoint, _ := store.Load("fake")
+	assert.True(t, checkpoint.After.IsZero())
+	assert.Equal(t, "2", checkpoint.Cursor)
+	assert.Equal(t, at(4, 0), checkpoint.Next)
+
+	// the restart resumes the interrupted pass and then moves the high-water mark
+	r.fail = nil
+	engine = NewEngine(store, r.handle, source)
+	assert.NoError(t, engine.Sync(ctx))
+	assert.Equal(t, []string{"a", "b", "c"}, r.take())
+
+	checkpoint, _ = store.Load("fake")
+	assert.Equal(t, at(4, 0), checkpoint.After)
+	assert.Equal(t, "", checkpoint.Cursor)
+	assert.Nil(t, checkpoint.NextBoundary)
+}
+
+func TestSyncCancelled(t *testing.T) {
+	source := &fakeSource{pageSize: 2, records: map[string]time.Time{"a": at(0, 0)}}
+	engine := NewEngine(NewMemoryStore(), (&recorder{}).handle, source)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, engine.Sync(ctx))
+	assert.Equal(t, 0, source.fetches)
+}
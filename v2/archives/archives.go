@@ -0,0 +1,138 @@
+package archives
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+const PATH = "/v2/archives.json"
+
+// Archive types
+const (
+	TypeMessage = "message"
+	TypeRun     = "run"
+)
+
+// Archive periods
+const (
+	PeriodDaily   = "daily"
+	PeriodMonthly = "monthly"
+)
+
+type ApiService struct {
+	serviceURL     string
+	requestHandler *rapidpro.RequestHandler
+	// DownloadClient is used to fetch archive files, http.DefaultClient is used when nil.
+	// Archive files are served from their own storage so the API token is never sent with them.
+	DownloadClient *http.Client
+}
+
+func NewService(requestHandler *rapidpro.RequestHandler, apiURL string) *ApiService {
+	return &ApiService{
+		requestHandler: requestHandler,
+		serviceURL:     apiURL + PATH,
+	}
+}
+
+// Get makes a GET request to archives endpoint with *QueryParams and returns a Response.
+func (s *ApiService) Get(params *QueryParams) (*Response, error) {
+	data := url.Values{}
+	headers := make(map[string]interface{})
+
+	if params != nil {
+		if params.ArchiveType != "" {
+			data.Set("archive_type", params.ArchiveType)
+		}
+		if params.Period != "" {
+			data.Set("period", params.Period)
+		}
+		if params.Before != nil {
+			data.Set("before", params.Before.Format(time.RFC3339))
+		}
+		if params.After != nil {
+			data.Set("after", params.After.Format(time.RFC3339))
+		}
+		if params.Cursor != "" {
+			data.Set("cursor", params.Cursor)
+		}
+	}
+
+	resp, err := s.requestHandler.Get(s.serviceURL, data, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Response{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Download fetches the gzipped JSONL file of an archive and returns a Reader that decodes its records one at a time.
+// The download is bound to ctx until the Reader is closed. The caller must Close the returned Reader.
+func (s *ApiService) Download(ctx context.Context, archive Archive) (*Reader, error) {
+	if archive.DownloadURL == "" {
+		return nil, errors.New("archive has no download url")
+	}
+
+	httpClient := s.DownloadClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, archive.DownloadURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("error downloading archive: unexpected status %d", resp.StatusCode)
+	}
+
+	reader, err := NewReader(resp.Body, archive)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return reader, nil
+}
+
+// Archive represents an archive object
+type Archive struct {
+	ArchiveType string `json:"archive_type,omitempty"`
+	StartDate   string `json:"start_date,omitempty"`
+	Period      string `json:"period,omitempty"`
+	RecordCount int    `json:"record_count,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+	Hash        string `json:"hash,omitempty"`
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+// Response represents the response of a request in archives endpoint
+type Response struct {
+	Next     interface{} `json:"next"`
+	Previous interface{} `json:"previous"`
+	Results  []Archive   `json:"results"`
+}
+
+// QueryParams is a struct that represents the query parameters that can be passed in a request to archives endpoint
+type QueryParams struct {
+	ArchiveType string     `json:"archive_type,omitempty"`
+	Period      string     `json:"period,omitempty"`
+	Before      *time.Time `json:"before,omitempty"`
+	After       *time.Time `json:"after,omitempty"`
+	Cursor      string     `json:"cursor,omitempty"`
+}
@@ -0,0 +1,218 @@
+package archives
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type ArchivesTestCase struct {
+	Label        string
+	Method       string
+	QueryParams  *QueryParams
+	Status       int
+	ResponseBody string
+	ResultsCount int
+	Error        error
+}
+
+var testCases = []ArchivesTestCase{
+	{
+		Label:        "Test Get archives",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       200,
+		ResponseBody: testData[0],
+		ResultsCount: 2,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Get archives with status 500 error",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       500,
+		ResponseBody: "{}",
+		ResultsCount: 0,
+		Error: &client.RapidproRestError{
+			Status:  500,
+			Details: map[string]interface{}{},
+		},
+	},
+	{
+		Label:        "Test Get archives with response error",
+		Method:       "GET",
+		Status:       200,
+		ResponseBody: "{",
+		Error:        errors.New("unexpected EOF"),
+	},
+	{
+		Label:  "Test Get archives with params",
+		Method: "GET",
+		QueryParams: &QueryParams{
+			ArchiveType: TypeMessage,
+			Period:      PeriodDaily,
+			After:       timeToPointer(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Before:      timeToPointer(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)),
+			Cursor:      "cD0yMDE3",
+		},
+		Status:       200,
+		ResponseBody: testData[1],
+		ResultsCount: 0,
+		Error:        nil,
+	},
+}
+
+func timeToPointer(t time.Time) *time.Time { return &t }
+
+func TestArchives(t *testing.T) {
+	for _, tc := range testCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if tc.QueryParams != nil {
+					assert.Equal(t, tc.QueryParams.ArchiveType, r.URL.Query().Get("archive_type"))
+					assert.Equal(t, tc.QueryParams.Period, r.URL.Query().Get("period"))
+					assert.Equal(t, "2022-01-01T00:00:00Z", r.URL.Query().Get("after"))
+					assert.Equal(t, "2022-01-02T00:00:00Z", r.URL.Query().Get("before"))
+					assert.Equal(t, tc.QueryParams.Cursor, r.URL.Query().Get("cursor"))
+				}
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			resp, err := service.Get(tc.QueryParams)
+			if err != nil {
+				assert.Equal(t, tc.Error.Error(), err.Error())
+			}
+			if tc.ResultsCount > 0 {
+				assert.Equal(t, tc.ResultsCount, len(resp.Results))
+				assert.Equal(t, TypeMessage, resp.Results[0].ArchiveType)
+				assert.Equal(t, int64(23421), resp.Results[0].Size)
+			}
+		})
+	}
+}
+
+func TestArchivesDownload(t *testing.T) {
+	file := gzipLines(t, messagesArchive)
+	fileServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get("Authorization"))
+			w.Write(file)
+		}))
+	defer fileServer.Close()
+
+	sum := md5.Sum(file)
+	archive := Archive{
+		ArchiveType: TypeMessage,
+		Size:        int64(len(file)),
+		Hash:        hex.EncodeToString(sum[:]),
+		DownloadURL: fileServer.URL,
+	}
+
+	service := NewService(nil, "")
+	reader, err := service.Download(context.Background(), archive)
+	assert.NoError(t, err)
+	defer reader.Close()
+
+	message, err := reader.NextMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, 4105426, message.ID)
+	message, err = reader.NextMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", message.Text)
+	_, err = reader.NextMessage()
+	assert.Equal(t, io.EOF, err)
+
+	_, err = service.Download(context.Background(), Archive{})
+	assert.Error(t, err)
+}
+
+func TestArchivesDownloadNotFound(t *testing.T) {
+	fileServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(404)
+		}))
+	defer fileServer.Close()
+
+	service := NewService(nil, "")
+	_, err := service.Download(context.Background(), Archive{DownloadURL: fileServer.URL})
+	assert.EqualError(t, err, "error downloading archive: unexpected status 404")
+}
+
+func TestArchivesDownloadCancelled(t *testing.T) {
+	fileServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+	defer fileServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	service := NewService(nil, "")
+	_, err := service.Download(ctx, Archive{DownloadURL: fileServer.URL})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func gzipLines(t *testing.T, lines string) []byte {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	_, err := gz.Write([]byte(lines))
+	assert.NoError(t, err)
+	assert.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+var testData = []string{
+	`{
+		"next": null,
+		"previous": null,
+		"results": [
+			{
+				"archive_type": "message",
+				"start_date": "2017-02-20",
+				"period": "daily",
+				"record_count": 1432,
+				"size": 23421,
+				"hash": "f0d79988b7772c003d04a28bd7417a62",
+				"download_url": "http://s3-bucket.aws.com/my/archive.jsonl.gz"
+			},
+			{
+				"archive_type": "run",
+				"start_date": "2017-02-01",
+				"period": "monthly",
+				"record_count": 10443,
+				"size": 283293,
+				"hash": "a0d79988b7772c003d04a28bd7417a62",
+				"download_url": "http://s3-bucket.aws.com/my/archive2.jsonl.gz"
+			}
+		]
+	}`,
+	`{
+		"next": null,
+		"previous": null,
+		"results": []
+	}`,
+}
+
+var messagesArchive = `{"id": 4105426, "contact": {"uuid": "d33e9ad5-5c35-414c-abd4-e7451c69ff1d", "name": "Bob McFlow"}, "direction": "out", "text": "How are you?", "created_on": "2016-01-06T15:33:00.813162Z"}
+{"id": 4105427, "contact": {"uuid": "d33e9ad5-5c35-414c-abd4-e7451c69ff1d", "name": "Bob McFlow"}, "direction": "in", "text": "Hello", "created_on": "2016-01-06T15:34:00.813162Z"}
+`
@@ -0,0 +1,114 @@
+package archives
+
+import (
+	"compress/gzip"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/v2/messages"
+	"github.com/rasoro/rapidpro-api-go/v2/runs"
+)
+
+var (
+	// ErrHashMismatch is returned when the downloaded file does not match the archive hash
+	ErrHashMismatch = errors.New("archive hash mismatch")
+	// ErrSizeMismatch is returned when the downloaded file does not match the archive size
+	ErrSizeMismatch = errors.New("archive size mismatch")
+)
+
+// Reader streams the records of a gzipped JSONL archive file. The hash and size of the
+// file are verified once the last record has been read, so a verification error is
+// returned in place of io.EOF.
+type Reader struct {
+	archive Archive
+	body    io.ReadCloser
+	raw     io.Reader
+	hasher  hash.Hash
+	size    int64
+	gz      *gzip.Reader
+	decoder *json.Decoder
+}
+
+// NewReader returns a Reader over the gzipped contents of r, verified against archive
+func NewReader(r io.ReadCloser, archive Archive) (*Reader, error) {
+	reader := &Reader{
+		archive: archive,
+		body:    r,
+		hasher:  md5.New(),
+	}
+	reader.raw = io.TeeReader(r, writerFunc(reader.write))
+
+	gz, err := gzip.NewReader(reader.raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening archive")
+	}
+	reader.gz = gz
+	reader.decoder = json.NewDecoder(gz)
+	return reader, nil
+}
+
+// Next decodes the next record into v, it returns io.EOF when there are no more records
+func (r *Reader) Next(v interface{}) error {
+	err := r.decoder.Decode(v)
+	if err == io.EOF {
+		if err := r.verify(); err != nil {
+			return err
+		}
+		return io.EOF
+	}
+	return err
+}
+
+// NextMessage decodes the next record of a message archive
+func (r *Reader) NextMessage() (*messages.Message, error) {
+	message := &messages.Message{}
+	if err := r.Next(message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// NextRun decodes the next record of a run archive
+func (r *Reader) NextRun() (*runs.Run, error) {
+	run := &runs.Run{}
+	if err := r.Next(run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// Close closes the underlying file
+func (r *Reader) Close() error {
+	r.gz.Close()
+	return r.body.Close()
+}
+
+func (r *Reader) write(p []byte) (int, error) {
+	r.size += int64(len(p))
+	return r.hasher.Write(p)
+}
+
+func (r *Reader) verify() error {
+	// consume anything left after the gzip stream so the whole file is hashed
+	if _, err := io.Copy(io.Discard, r.raw); err != nil {
+		return err
+	}
+	if r.archive.Size != 0 && r.size != r.archive.Size {
+		return errors.Wrapf(ErrSizeMismatch, "expected %d bytes, got %d", r.archive.Size, r.size)
+	}
+	if r.archive.Hash != "" {
+		sum := hex.EncodeToString(r.hasher.Sum(nil))
+		if sum != r.archive.Hash {
+			return errors.Wrapf(ErrHashMismatch, "expected %s, got %s", r.archive.Hash, sum)
+		}
+	}
+	return nil
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }
@@ -0,0 +1,55 @@
+package archives
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var runsArchive = `{"id": 12345678, "uuid": "d0be5a4c-a0a4-4b8e-9bcb-ce4d2e0c8bfa", "flow": {"uuid": "f5901b62-ba76-4003-9c62-72fdacc1b7b7", "name": "Favorite Color"}, "responded": true, "values": {"color": {"value": "blue", "category": "Blue"}}, "exit_type": "completed"}
+`
+
+func TestReaderRuns(t *testing.T) {
+	file := gzipLines(t, runsArchive)
+	sum := md5.Sum(file)
+	archive := Archive{ArchiveType: TypeRun, Size: int64(len(file)), Hash: hex.EncodeToString(sum[:])}
+
+	reader, err := NewReader(ioutil.NopCloser(bytes.NewReader(file)), archive)
+	assert.NoError(t, err)
+	defer reader.Close()
+
+	run, err := reader.NextRun()
+	assert.NoError(t, err)
+	assert.Equal(t, "Blue", run.Values["color"].Category)
+	_, err = reader.NextRun()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestReaderVerification(t *testing.T) {
+	file := gzipLines(t, runsArchive)
+
+	reader, err := NewReader(ioutil.NopCloser(bytes.NewReader(file)), Archive{Hash: "a0d79988b7772c003d04a28bd7417a62"})
+	assert.NoError(t, err)
+	_, err = reader.NextRun()
+	assert.NoError(t, err)
+	_, err = reader.NextRun()
+	assert.Equal(t, ErrHashMismatch, errors.Cause(err))
+
+	reader, err = NewReader(ioutil.NopCloser(bytes.NewReader(file)), Archive{Size: 1})
+	assert.NoError(t, err)
+	_, err = reader.NextRun()
+	assert.NoError(t, err)
+	_, err = reader.NextRun()
+	assert.Equal(t, ErrSizeMismatch, errors.Cause(err))
+}
+
+func TestReaderInvalidFile(t *testing.T) {
+	_, err := NewReader(ioutil.NopCloser(bytes.NewReader([]byte("not gzip"))), Archive{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package boundaries
+
+import (
+	"encoding/json"
+	"net/url"
+
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+const PATH = "/v2/boundaries.json"
+
+// Boundary levels
+const (
+	LevelCountry  = 0
+	LevelState    = 1
+	LevelDistrict = 2
+	LevelWard     = 3
+)
+
+type ApiService struct {
+	serviceURL     string
+	requestHandler *rapidpro.RequestHandler
+}
+
+func NewService(requestHandler *rapidpro.RequestHandler, apiURL string) *ApiService {
+	return &ApiService{
+		requestHandler: requestHandler,
+		serviceURL:     apiURL + PATH,
+	}
+}
+
+// Get makes a GET request to boundaries endpoint with *QueryParams and returns a Response.
+func (s *ApiService) Get(params *QueryParams) (*Response, error) {
+	data := url.Values{}
+	headers := make(map[string]interface{})
+
+	if params != nil {
+		if params.Geometry {
+			data.Set("geometry", "true")
+		}
+		if params.Cursor != "" {
+			data.Set("cursor", params.Cursor)
+		}
+	}
+
+	resp, err := s.requestHandler.Get(s.serviceURL, data, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Response{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Boundary represents an administrative boundary object
+type Boundary struct {
+	OSMID  string `json:"osm_id"`
+	Name   string `json:"name"`
+	Parent *struct {
+		OSMID string `json:"osm_id"`
+		Name  string `json:"name"`
+	} `json:"parent"`
+	Level    int       `json:"level"`
+	Aliases  []string  `json:"aliases"`
+	Geometry *Geometry `json:"geometry,omitempty"`
+}
+
+// Geometry represents the simplified GeoJSON geometry of a boundary, only returned when requested
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// Response represents the response of a request in boundaries endpoint
+type Response struct {
+	Next     interface{} `json:"next"`
+	Previous interface{} `json:"previous"`
+	Results  []Boundary  `json:"results"`
+}
+
+// QueryParams is a struct that represents the query parameters that can be passed in a request to boundaries endpoint
+type QueryParams struct {
+	Geometry bool   `json:"geometry,omitempty"`
+	Cursor   string `json:"cursor,omitempty"`
+}
@@ -0,0 +1,118 @@
+package boundaries
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type BoundariesTestCase struct {
+	Label        string
+	Method       string
+	QueryParams  *QueryParams
+	Status       int
+	ResponseBody string
+	ResultsCount int
+	Error        error
+}
+
+var testCases = []BoundariesTestCase{
+	{
+		Label:        "Test Get boundaries",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       200,
+		ResponseBody: testData,
+		ResultsCount: 4,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Get boundaries with response error",
+		Method:       "GET",
+		Status:       200,
+		ResponseBody: "{",
+		Error:        errors.New("unexpected EOF"),
+	},
+	{
+		Label:        "Test Get boundaries with geometry",
+		Method:       "GET",
+		QueryParams:  &QueryParams{Geometry: true},
+		Status:       200,
+		ResponseBody: testData,
+		ResultsCount: 4,
+		Error:        nil,
+	},
+}
+
+func TestBoundaries(t *testing.T) {
+	for _, tc := range testCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if tc.QueryParams != nil {
+					assert.Equal(t, "true", r.URL.Query().Get("geometry"))
+				}
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			resp, err := service.Get(tc.QueryParams)
+			if err != nil {
+				assert.Equal(t, tc.Error.Error(), err.Error())
+			}
+			if tc.ResultsCount > 0 {
+				assert.Equal(t, tc.ResultsCount, len(resp.Results))
+				assert.Nil(t, resp.Results[0].Parent)
+				assert.Equal(t, "MultiPolygon", resp.Results[0].Geometry.Type)
+				assert.Equal(t, LevelState, resp.Results[1].Level)
+			}
+		})
+	}
+}
+
+var testData = `{
+	"next": null,
+	"previous": null,
+	"results": [
+		{
+			"osm_id": "1708283",
+			"name": "Rwanda",
+			"parent": null,
+			"level": 0,
+			"aliases": [],
+			"geometry": {"type": "MultiPolygon", "coordinates": [[[[30.1, -1.9], [30.2, -1.9], [30.2, -2.0], [30.1, -1.9]]]]}
+		},
+		{
+			"osm_id": "1708286",
+			"name": "Kigali City",
+			"parent": {"osm_id": "1708283", "name": "Rwanda"},
+			"level": 1,
+			"aliases": ["Kigali", "Kigari"]
+		},
+		{
+			"osm_id": "3963734",
+			"name": "Nyarugenge",
+			"parent": {"osm_id": "1708286", "name": "Kigali City"},
+			"level": 2,
+			"aliases": []
+		},
+		{
+			"osm_id": "1708287",
+			"name": "Eastern Province",
+			"parent": {"osm_id": "1708283", "name": "Rwanda"},
+			"level": 1,
+			"aliases": []
+		}
+	]
+}`
@@ -0,0 +1,53 @@
+package boundaries
+
+// FeatureCollection is a GeoJSON feature collection of boundaries
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// Feature is a GeoJSON feature of a boundary
+type Feature struct {
+	Type       string            `json:"type"`
+	ID         string            `json:"id"`
+	Geometry   *Geometry         `json:"geometry"`
+	Properties FeatureProperties `json:"properties"`
+}
+
+// FeatureProperties are the properties of a boundary Feature
+type FeatureProperties struct {
+	OSMID       string   `json:"osm_id"`
+	Name        string   `json:"name"`
+	Level       int      `json:"level"`
+	ParentOSMID string   `json:"parent_osm_id,omitempty"`
+	Aliases     []string `json:"aliases,omitempty"`
+}
+
+// NewFeatureCollection returns the boundaries as a GeoJSON feature collection ready to be marshaled,
+// boundaries fetched without geometry have a null geometry
+func NewFeatureCollection(boundaries []Boundary) *FeatureCollection {
+	collection := &FeatureCollection{Type: "FeatureCollection", Features: make([]Feature, 0, len(boundaries))}
+	for _, boundary := range boundaries {
+		properties := FeatureProperties{
+			OSMID:   boundary.OSMID,
+			Name:    boundary.Name,
+			Level:   boundary.Level,
+			Aliases: boundary.Aliases,
+		}
+		if boundary.Parent != nil {
+			properties.ParentOSMID = boundary.Parent.OSMID
+		}
+		collection.Features = append(collection.Features, Feature{
+			Type:       "Feature",
+			ID:         boundary.OSMID,
+			Geometry:   boundary.Geometry,
+			Properties: properties,
+		})
+	}
+	return collection
+}
+
+// FeatureCollection returns the boundaries of the tree as a GeoJSON feature collection
+func (t *Tree) FeatureCollection() *FeatureCollection {
+	return NewFeatureCollection(t.Boundaries())
+}
@@ -0,0 +1,37 @@
+package boundaries
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureCollection(t *testing.T) {
+	tree := NewTree(decodeTestBoundaries(t))
+
+	encoded, err := json.Marshal(tree.FeatureCollection())
+	assert.NoError(t, err)
+
+	collection := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(encoded, &collection))
+	assert.Equal(t, "FeatureCollection", collection["type"])
+
+	features := collection["features"].([]interface{})
+	assert.Equal(t, 4, len(features))
+
+	rwanda := features[0].(map[string]interface{})
+	assert.Equal(t, "Feature", rwanda["type"])
+	assert.Equal(t, "1708283", rwanda["id"])
+	assert.Equal(t, "MultiPolygon", rwanda["geometry"].(map[string]interface{})["type"])
+
+	kigali := features[2].(map[string]interface{})
+	assert.Nil(t, kigali["geometry"])
+	assert.Equal(t, map[string]interface{}{
+		"osm_id":        "1708286",
+		"name":          "Kigali City",
+		"level":         float64(1),
+		"parent_osm_id": "1708283",
+		"aliases":       []interface{}{"Kigali", "Kigari"},
+	}, kigali["properties"])
+}
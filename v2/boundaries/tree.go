@@ -0,0 +1,111 @@
+package boundaries
+
+import (
+	"sort"
+	"strings"
+)
+
+// Node is a boundary in a Tree along with the boundaries it contains
+type Node struct {
+	Boundary Boundary
+	Parent   *Node
+	Children []*Node
+}
+
+// Tree is the parent/child hierarchy of a set of boundaries
+type Tree struct {
+	Roots []*Node
+	nodes map[string]*Node
+}
+
+// NewTree assembles boundaries into a tree, boundaries whose parent isn't in the set become roots.
+// Roots and children are sorted by name.
+func NewTree(boundaries []Boundary) *Tree {
+	tree := &Tree{nodes: make(map[string]*Node, len(boundaries))}
+	for _, boundary := range boundaries {
+		tree.nodes[boundary.OSMID] = &Node{Boundary: boundary}
+	}
+
+	for _, boundary := range boundaries {
+		node := tree.nodes[boundary.OSMID]
+		var parent *Node
+		if boundary.Parent != nil {
+			parent = tree.nodes[boundary.Parent.OSMID]
+		}
+		if parent == nil {
+			tree.Roots = append(tree.Roots, node)
+			continue
+		}
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+
+	sortNodes(tree.Roots)
+	for _, node := range tree.nodes {
+		sortNodes(node.Children)
+	}
+	return tree
+}
+
+// Node returns the node of the boundary with the given OSM id, or nil if it isn't in the tree
+func (t *Tree) Node(osmID string) *Node {
+	return t.nodes[osmID]
+}
+
+// Walk visits every node depth first, parents before their children
+func (t *Tree) Walk(fn func(node *Node)) {
+	var walk func(nodes []*Node)
+	walk = func(nodes []*Node) {
+		for _, node := range nodes {
+			fn(node)
+			walk(node.Children)
+		}
+	}
+	walk(t.Roots)
+}
+
+// Boundaries returns the boundaries of the tree depth first
+func (t *Tree) Boundaries() []Boundary {
+	boundaries := make([]Boundary, 0, len(t.nodes))
+	t.Walk(func(node *Node) {
+		boundaries = append(boundaries, node.Boundary)
+	})
+	return boundaries
+}
+
+// Path returns the names of the node and its ancestors starting from the root, e.g. [Rwanda, Kigali City, Nyarugenge]
+func (n *Node) Path() []string {
+	var path []string
+	for node := n; node != nil; node = node.Parent {
+		path = append([]string{node.Boundary.Name}, path...)
+	}
+	return path
+}
+
+// Child returns the child with the given name or alias, ignoring case, or nil if there is none
+func (n *Node) Child(name string) *Node {
+	for _, child := range n.Children {
+		if matchesName(child.Boundary, name) {
+			return child
+		}
+	}
+	return nil
+}
+
+func sortNodes(nodes []*Node) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].Boundary.Name < nodes[j].Boundary.Name
+	})
+}
+
+func matchesName(boundary Boundary, name string) bool {
+	if strings.EqualFold(boundary.Name, name) {
+		return true
+	}
+	for _, alias := range boundary.Aliases {
+		if strings.EqualFold(alias, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package boundaries
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeTestBoundaries(t *testing.T) []Boundary {
+	response := &Response{}
+	assert.NoError(t, json.Unmarshal([]byte(testData), response))
+	return response.Results
+}
+
+func TestTree(t *testing.T) {
+	tree := NewTree(decodeTestBoundaries(t))
+
+	assert.Equal(t, 1, len(tree.Roots))
+	rwanda := tree.Roots[0]
+	assert.Equal(t, "Rwanda", rwanda.Boundary.Name)
+	assert.Equal(t, 2, len(rwanda.Children))
+	assert.Equal(t, "Eastern Province", rwanda.Children[0].Boundary.Name)
+
+	kigali := rwanda.Child("kigari")
+	assert.Equal(t, "1708286", kigali.Boundary.OSMID)
+	assert.Nil(t, rwanda.Child("Nairobi"))
+
+	nyarugenge := tree.Node("3963734")
+	assert.Equal(t, []string{"Rwanda", "Kigali City", "Nyarugenge"}, nyarugenge.Path())
+	assert.Nil(t, tree.Node("0"))
+
+	var names []string
+	for _, boundary := range tree.Boundaries() {
+		names = append(names, boundary.Name)
+	}
+	assert.Equal(t, []string{"Rwanda", "Eastern Province", "Kigali City", "Nyarugenge"}, names)
+}
+
+func TestTreeOrphans(t *testing.T) {
+	boundaries := decodeTestBoundaries(t)
+	tree := NewTree(boundaries[1:])
+
+	assert.Equal(t, 2, len(tree.Roots))
+	assert.Equal(t, "Eastern Province", tree.Roots[0].Boundary.Name)
+	assert.Equal(t, "Kigali City", tree.Roots[1].Boundary.Name)
+	assert.Nil(t, tree.Roots[1].Parent)
+}
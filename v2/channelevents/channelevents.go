@@ -0,0 +1,101 @@
+package channelevents
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+const PATH = "/v2/channel_events.json"
+
+// Event types that can be returned by the channel events endpoint
+const (
+	TypeCallIn          = "call-in"
+	TypeCallInMissed    = "call-in-missed"
+	TypeCallOut         = "call-out"
+	TypeCallOutMissed   = "call-out-missed"
+	TypeNewConversation = "new_conversation"
+	TypeReferral        = "referral"
+	TypeStopContact     = "stop_contact"
+)
+
+type ApiService struct {
+	serviceURL     string
+	requestHandler *rapidpro.RequestHandler
+}
+
+func NewService(requestHandler *rapidpro.RequestHandler, apiURL string) *ApiService {
+	return &ApiService{
+		requestHandler: requestHandler,
+		serviceURL:     apiURL + PATH,
+	}
+}
+
+// Get makes a GET request to channel events endpoint with *QueryParams and returns a Response.
+func (s *ApiService) Get(params *QueryParams) (*Response, error) {
+	data := url.Values{}
+	headers := make(map[string]interface{})
+
+	if params != nil {
+		if params.ID != 0 {
+			data.Set("id", strconv.Itoa(params.ID))
+		}
+		if params.Contact != "" {
+			data.Set("contact", params.Contact)
+		}
+		if params.Before != nil {
+			data.Set("before", params.Before.Format(time.RFC3339))
+		}
+		if params.After != nil {
+			data.Set("after", params.After.Format(time.RFC3339))
+		}
+	}
+
+	resp, err := s.requestHandler.Get(s.serviceURL, data, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Response{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ChannelEvent represents a channel event object
+type ChannelEvent struct {
+	ID      int    `json:"id,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Contact struct {
+		UUID string `json:"uuid,omitempty"`
+		Name string `json:"name,omitempty"`
+	} `json:"contact,omitempty"`
+	Channel struct {
+		UUID string `json:"uuid,omitempty"`
+		Name string `json:"name,omitempty"`
+	} `json:"channel,omitempty"`
+	Extra      map[string]interface{} `json:"extra,omitempty"`
+	OccurredOn *time.Time             `json:"occurred_on,omitempty"`
+	CreatedOn  *time.Time             `json:"created_on,omitempty"`
+}
+
+// Response represents the response of a request in channel events endpoint
+type Response struct {
+	Next     interface{}    `json:"next"`
+	Previous interface{}    `json:"previous"`
+	Results  []ChannelEvent `json:"results"`
+}
+
+// QueryParams is a struct that represents the query parameters that can be passed in a request to channel events endpoint
+type QueryParams struct {
+	ID      int        `json:"id,omitempty"`
+	Contact string     `json:"contact,omitempty"`
+	Before  *time.Time `json:"before,omitempty"`
+	After   *time.Time `json:"after,omitempty"`
+}
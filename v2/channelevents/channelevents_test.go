@@ -0,0 +1,136 @@
+package channelevents
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type ChannelEventsTestCase struct {
+	Label        string
+	Method       string
+	QueryParams  *QueryParams
+	Status       int
+	ResponseBody string
+	ResultsCount int
+	Error        error
+}
+
+var testCases = []ChannelEventsTestCase{
+	{
+		Label:        "Test Get channel events",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       200,
+		ResponseBody: testData[0],
+		ResultsCount: 2,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Get channel events with status 500 error",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       500,
+		ResponseBody: "{}",
+		ResultsCount: 0,
+		Error: &client.RapidproRestError{
+			Status:  500,
+			Details: map[string]interface{}{},
+		},
+	},
+	{
+		Label:        "Test Get channel events with response error",
+		Method:       "GET",
+		Status:       200,
+		ResponseBody: "{",
+		Error:        errors.New("unexpected EOF"),
+	},
+	{
+		Label:  "Test Get channel events with params",
+		Method: "GET",
+		QueryParams: &QueryParams{
+			ID:      4,
+			Contact: "d33e9ad5-5c35-414c-abd4-e7451c69ff1d",
+			After:   timeToPointer(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Before:  timeToPointer(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		Status:       200,
+		ResponseBody: testData[1],
+		ResultsCount: 0,
+		Error:        nil,
+	},
+}
+
+func timeToPointer(t time.Time) *time.Time { return &t }
+
+func TestChannelEvents(t *testing.T) {
+	for _, tc := range testCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if tc.QueryParams != nil {
+					assert.Equal(t, "4", r.URL.Query().Get("id"))
+					assert.Equal(t, tc.QueryParams.Contact, r.URL.Query().Get("contact"))
+					assert.Equal(t, "2022-01-01T00:00:00Z", r.URL.Query().Get("after"))
+					assert.Equal(t, "2022-01-02T00:00:00Z", r.URL.Query().Get("before"))
+				}
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			resp, err := service.Get(tc.QueryParams)
+			if err != nil {
+				assert.Equal(t, tc.Error.Error(), err.Error())
+			}
+			if tc.ResultsCount > 0 {
+				assert.Equal(t, tc.ResultsCount, len(resp.Results))
+				assert.Equal(t, TypeCallIn, resp.Results[0].Type)
+				assert.Equal(t, float64(606), resp.Results[0].Extra["duration"])
+			}
+		})
+	}
+}
+
+var testData = []string{
+	`{
+		"next": null,
+		"previous": null,
+		"results": [
+			{
+				"id": 4,
+				"channel": {"uuid": "9a8b001e-a913-486c-80f4-1356e23f582e", "name": "Nexmo"},
+				"type": "call-in",
+				"contact": {"uuid": "d33e9ad5-5c35-414c-abd4-e7451c69ff1d", "name": "Bob McFlow"},
+				"extra": {"duration": 606},
+				"occurred_on": "2013-02-27T09:06:12.123Z",
+				"created_on": "2013-02-27T09:06:15.456Z"
+			},
+			{
+				"id": 5,
+				"channel": {"uuid": "9a8b001e-a913-486c-80f4-1356e23f582e", "name": "Nexmo"},
+				"type": "stop_contact",
+				"contact": {"uuid": "d33e9ad5-5c35-414c-abd4-e7451c69ff1d", "name": "Bob McFlow"},
+				"extra": null,
+				"occurred_on": "2013-02-28T09:06:12.123Z",
+				"created_on": "2013-02-28T09:06:15.456Z"
+			}
+		]
+	}`,
+	`{
+		"next": null,
+		"previous": null,
+		"results": []
+	}`,
+}
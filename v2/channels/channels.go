@@ -0,0 +1,84 @@
+package channels
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+const PATH = "/v2/channels.json"
+
+type ApiService struct {
+	serviceURL     string
+	requestHandler *rapidpro.RequestHandler
+}
+
+func NewService(requestHandler *rapidpro.RequestHandler, apiURL string) *ApiService {
+	return &ApiService{
+		requestHandler: requestHandler,
+		serviceURL:     apiURL + PATH,
+	}
+}
+
+// Get makes a GET request to channels endpoint with *QueryParams and returns a Response.
+func (s *ApiService) Get(params *QueryParams) (*Response, error) {
+	data := url.Values{}
+	headers := make(map[string]interface{})
+
+	if params != nil {
+		if params.UUID != "" {
+			data.Set("uuid", params.UUID)
+		}
+		if params.Address != "" {
+			data.Set("address", params.Address)
+		}
+	}
+
+	resp, err := s.requestHandler.Get(s.serviceURL, data, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Response{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Channel represents a channel object
+type Channel struct {
+	UUID      string     `json:"uuid,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	Address   string     `json:"address,omitempty"`
+	Country   string     `json:"country,omitempty"`
+	Device    *Device    `json:"device,omitempty"`
+	LastSeen  *time.Time `json:"last_seen,omitempty"`
+	CreatedOn *time.Time `json:"created_on,omitempty"`
+}
+
+// Device represents the Android device of a channel, it is nil for non Android channels
+type Device struct {
+	Name        string `json:"name,omitempty"`
+	PowerLevel  int    `json:"power_level,omitempty"`
+	PowerStatus string `json:"power_status,omitempty"`
+	PowerSource string `json:"power_source,omitempty"`
+	NetworkType string `json:"network_type,omitempty"`
+}
+
+// Response represents the response of a request in channels endpoint
+type Response struct {
+	Next     interface{} `json:"next"`
+	Previous interface{} `json:"previous"`
+	Results  []Channel   `json:"results"`
+}
+
+// QueryParams is a struct that represents the query parameters that can be passed in a request to channels endpoint
+type QueryParams struct {
+	UUID    string `json:"uuid,omitempty"`
+	Address string `json:"address,omitempty"`
+}
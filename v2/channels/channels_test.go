@@ -0,0 +1,135 @@
+package channels
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type ChannelsTestCase struct {
+	Label        string
+	Method       string
+	QueryParams  *QueryParams
+	Status       int
+	ResponseBody string
+	ResultsCount int
+	Error        error
+}
+
+var testCases = []ChannelsTestCase{
+	{
+		Label:        "Test Get channels",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       200,
+		ResponseBody: testData[0],
+		ResultsCount: 2,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Get channels with status 500 error",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       500,
+		ResponseBody: "{}",
+		ResultsCount: 0,
+		Error: &client.RapidproRestError{
+			Status:  500,
+			Details: map[string]interface{}{},
+		},
+	},
+	{
+		Label:        "Test Get channels with response error",
+		Method:       "GET",
+		Status:       200,
+		ResponseBody: "{",
+		Error:        errors.New("unexpected EOF"),
+	},
+	{
+		Label:  "Test Get channels with params",
+		Method: "GET",
+		QueryParams: &QueryParams{
+			UUID:    "09d23a05-47fe-11e4-bfe9-b8f6b119e9ab",
+			Address: "+250788123123",
+		},
+		Status:       200,
+		ResponseBody: testData[1],
+		ResultsCount: 0,
+		Error:        nil,
+	},
+}
+
+func TestChannels(t *testing.T) {
+	for _, tc := range testCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if tc.QueryParams != nil {
+					assert.Equal(t, tc.QueryParams.UUID, r.URL.Query().Get("uuid"))
+					assert.Equal(t, tc.QueryParams.Address, r.URL.Query().Get("address"))
+				}
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			resp, err := service.Get(tc.QueryParams)
+			if err != nil {
+				assert.Equal(t, tc.Error.Error(), err.Error())
+			}
+			if tc.ResultsCount > 0 {
+				assert.Equal(t, tc.ResultsCount, len(resp.Results))
+				assert.Equal(t, 99, resp.Results[0].Device.PowerLevel)
+				assert.Nil(t, resp.Results[1].Device)
+			}
+		})
+	}
+}
+
+var testData = []string{
+	`{
+		"next": null,
+		"previous": null,
+		"results": [
+			{
+				"uuid": "09d23a05-47fe-11e4-bfe9-b8f6b119e9ab",
+				"name": "Android Phone",
+				"address": "+250788123123",
+				"country": "RW",
+				"device": {
+					"name": "Nexus 5X",
+					"power_level": 99,
+					"power_status": "STATUS_DISCHARGING",
+					"power_source": "BATTERY",
+					"network_type": "WIFI"
+				},
+				"last_seen": "2016-03-01T05:31:27.456Z",
+				"created_on": "2014-06-23T09:34:12.866569Z"
+			},
+			{
+				"uuid": "9a8b001e-a913-486c-80f4-1356e23f582e",
+				"name": "Vonage",
+				"address": "+12065551212",
+				"country": "US",
+				"device": null,
+				"last_seen": null,
+				"created_on": "2015-02-11T10:14:12.866569Z"
+			}
+		]
+	}`,
+	`{
+		"next": null,
+		"previous": null,
+		"results": []
+	}`,
+}
@@ -0,0 +1,86 @@
+package classifiers
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+const PATH = "/v2/classifiers.json"
+
+// Classifier types
+const (
+	TypeWit    = "wit"
+	TypeLuis   = "luis"
+	TypeBothub = "bothub"
+)
+
+type ApiService struct {
+	serviceURL     string
+	requestHandler *rapidpro.RequestHandler
+}
+
+func NewService(requestHandler *rapidpro.RequestHandler, apiURL string) *ApiService {
+	return &ApiService{
+		requestHandler: requestHandler,
+		serviceURL:     apiURL + PATH,
+	}
+}
+
+// Get makes a GET request to classifiers endpoint with *QueryParams and returns a Response.
+func (s *ApiService) Get(params *QueryParams) (*Response, error) {
+	data := url.Values{}
+	headers := make(map[string]interface{})
+
+	if params != nil {
+		if params.UUID != "" {
+			data.Set("uuid", params.UUID)
+		}
+	}
+
+	resp, err := s.requestHandler.Get(s.serviceURL, data, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Response{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Classifier represents a classifier object
+type Classifier struct {
+	UUID      string     `json:"uuid,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	Type      string     `json:"type,omitempty"`
+	Intents   []string   `json:"intents,omitempty"`
+	CreatedOn *time.Time `json:"created_on,omitempty"`
+}
+
+// HasIntent returns whether the classifier can classify input into the given intent
+func (c *Classifier) HasIntent(intent string) bool {
+	for _, i := range c.Intents {
+		if i == intent {
+			return true
+		}
+	}
+	return false
+}
+
+// Response represents the response of a request in classifiers endpoint
+type Response struct {
+	Next     interface{}  `json:"next"`
+	Previous interface{}  `json:"previous"`
+	Results  []Classifier `json:"results"`
+}
+
+// QueryParams is a struct that represents the query parameters that can be passed in a request to classifiers endpoint
+type QueryParams struct {
+	UUID string `json:"uuid,omitempty"`
+}
@@ -0,0 +1,101 @@
+package classifiers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type ClassifiersTestCase struct {
+	Label        string
+	Method       string
+	QueryParams  *QueryParams
+	Status       int
+	ResponseBody string
+	ResultsCount int
+	Error        error
+}
+
+var testCases = []ClassifiersTestCase{
+	{
+		Label:        "Test Get classifiers",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       200,
+		ResponseBody: testData[0],
+		ResultsCount: 1,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Get classifiers with response error",
+		Method:       "GET",
+		Status:       200,
+		ResponseBody: "{",
+		Error:        errors.New("unexpected EOF"),
+	},
+	{
+		Label:        "Test Get classifiers with params",
+		Method:       "GET",
+		QueryParams:  &QueryParams{UUID: "9a8b001e-a913-486c-80f4-1356e23f582e"},
+		Status:       200,
+		ResponseBody: testData[1],
+		ResultsCount: 0,
+		Error:        nil,
+	},
+}
+
+func TestClassifiers(t *testing.T) {
+	for _, tc := range testCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			resp, err := service.Get(tc.QueryParams)
+			if err != nil {
+				assert.Equal(t, tc.Error.Error(), err.Error())
+			}
+			if tc.ResultsCount > 0 {
+				assert.Equal(t, tc.ResultsCount, len(resp.Results))
+				classifier := resp.Results[0]
+				assert.Equal(t, TypeWit, classifier.Type)
+				assert.True(t, classifier.HasIntent("book_flight"))
+				assert.False(t, classifier.HasIntent("cancel_flight"))
+			}
+		})
+	}
+}
+
+var testData = []string{
+	`{
+		"next": null,
+		"previous": null,
+		"results": [
+			{
+				"uuid": "9a8b001e-a913-486c-80f4-1356e23f582e",
+				"name": "Booking",
+				"type": "wit",
+				"intents": ["book_flight", "book_hotel"],
+				"created_on": "2013-02-27T09:06:15.456Z"
+			}
+		]
+	}`,
+	`{
+		"next": null,
+		"previous": null,
+		"results": []
+	}`,
+}
@@ -0,0 +1,151 @@
+package definitions
+
+import "encoding/json"
+
+// Action types
+const (
+	ActionTypeAddContactGroups    = "add_contact_groups"
+	ActionTypeAddContactURN       = "add_contact_urn"
+	ActionTypeAddInputLabels      = "add_input_labels"
+	ActionTypeCallClassifier      = "call_classifier"
+	ActionTypeCallResthook        = "call_resthook"
+	ActionTypeCallWebhook         = "call_webhook"
+	ActionTypeEnterFlow           = "enter_flow"
+	ActionTypeOpenTicket          = "open_ticket"
+	ActionTypePlayAudio           = "play_audio"
+	ActionTypeRemoveContactGroups = "remove_contact_groups"
+	ActionTypeRequestOptIn        = "request_optin"
+	ActionTypeSayMsg              = "say_msg"
+	ActionTypeSendBroadcast       = "send_broadcast"
+	ActionTypeSendEmail           = "send_email"
+	ActionTypeSendMsg             = "send_msg"
+	ActionTypeSetContactChannel   = "set_contact_channel"
+	ActionTypeSetContactField     = "set_contact_field"
+	ActionTypeSetContactLanguage  = "set_contact_language"
+	ActionTypeSetContactName      = "set_contact_name"
+	ActionTypeSetContactTimezone  = "set_contact_timezone"
+	ActionTypeSetRunResult        = "set_run_result"
+	ActionTypeStartSession        = "start_session"
+)
+
+// Action represents a flow action, only the fields relevant to its Type are set
+type Action struct {
+	UUID string `json:"uuid"`
+	Type string `json:"type"`
+
+	// send_msg, send_broadcast, say_msg and play_audio
+	Text         string      `json:"text,omitempty"`
+	Attachments  []string    `json:"attachments,omitempty"`
+	QuickReplies []string    `json:"quick_replies,omitempty"`
+	AllURNs      bool        `json:"all_urns,omitempty"`
+	Templating   *Templating `json:"templating,omitempty"`
+	AudioURL     string      `json:"audio_url,omitempty"`
+
+	// send_email
+	Addresses []string `json:"addresses,omitempty"`
+	Subject   string   `json:"subject,omitempty"`
+
+	// call_webhook, send_email and open_ticket
+	Method  string            `json:"method,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+
+	// set_contact_*, set_run_result and add_contact_urn
+	Field    *FieldReference `json:"field,omitempty"`
+	Name     string          `json:"name,omitempty"`
+	Value    string          `json:"value,omitempty"`
+	Category string          `json:"category,omitempty"`
+	Language string          `json:"language,omitempty"`
+	Timezone string          `json:"timezone,omitempty"`
+	Channel  *Reference      `json:"channel,omitempty"`
+	Scheme   string          `json:"scheme,omitempty"`
+	Path     string          `json:"path,omitempty"`
+
+	// add_contact_groups, remove_contact_groups, add_input_labels, send_broadcast and start_session
+	Groups        []GroupReference `json:"groups,omitempty"`
+	AllGroups     bool             `json:"all_groups,omitempty"`
+	Labels        []Reference      `json:"labels,omitempty"`
+	Contacts      []Reference      `json:"contacts,omitempty"`
+	URNs          []string         `json:"urns,omitempty"`
+	LegacyVars    []string         `json:"legacy_vars,omitempty"`
+	ContactQuery  string           `json:"contact_query,omitempty"`
+	CreateContact bool             `json:"create_contact,omitempty"`
+
+	// enter_flow and start_session
+	Flow     *Reference `json:"flow,omitempty"`
+	Terminal bool       `json:"terminal,omitempty"`
+
+	// call_classifier, call_resthook and open_ticket
+	Classifier *Reference     `json:"classifier,omitempty"`
+	Input      string         `json:"input,omitempty"`
+	Resthook   string         `json:"resthook,omitempty"`
+	Ticketer   *Reference     `json:"ticketer,omitempty"`
+	Topic      *Topic         `json:"topic,omitempty"`
+	Assignee   *UserReference `json:"assignee,omitempty"`
+	OptIn      *Reference     `json:"optin,omitempty"`
+
+	ResultName string `json:"result_name,omitempty"`
+}
+
+// Reference represents a reference to an object by uuid and name
+type Reference struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+}
+
+// GroupReference represents a reference to a group, a group can also be referenced by a name match query
+type GroupReference struct {
+	UUID      string `json:"uuid,omitempty"`
+	Name      string `json:"name,omitempty"`
+	NameMatch string `json:"name_match,omitempty"`
+}
+
+// FieldReference represents a reference to a contact field
+type FieldReference struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// UserReference represents a reference to a user
+type UserReference struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// Templating represents the WhatsApp template used by a send_msg action
+type Templating struct {
+	UUID      string    `json:"uuid"`
+	Template  Reference `json:"template"`
+	Variables []string  `json:"variables,omitempty"`
+}
+
+// Topic is a ticket topic reference in open_ticket actions and a plain message topic in send_msg actions,
+// the latter only has a Name
+type Topic struct {
+	UUID string
+	Name string
+}
+
+// UnmarshalJSON decodes a topic from either a reference object or a string
+func (t *Topic) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		t.UUID, t.Name = "", name
+		return nil
+	}
+	ref := Reference{}
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return err
+	}
+	t.UUID, t.Name = ref.UUID, ref.Name
+	return nil
+}
+
+// MarshalJSON encodes a topic back in the form it was decoded from
+func (t Topic) MarshalJSON() ([]byte, error) {
+	if t.UUID == "" {
+		return json.Marshal(t.Name)
+	}
+	return json.Marshal(Reference{UUID: t.UUID, Name: t.Name})
+}
@@ -0,0 +1,102 @@
+package definitions
+
+import (
+	"encoding/json"
+	"net/url"
+
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+const PATH = "/v2/definitions.json"
+
+// Dependencies that can be included in an export
+const (
+	DependenciesNone  = "none"
+	DependenciesFlows = "flows"
+	DependenciesAll   = "all"
+)
+
+type ApiService struct {
+	serviceURL     string
+	requestHandler *rapidpro.RequestHandler
+}
+
+func NewService(requestHandler *rapidpro.RequestHandler, apiURL string) *ApiService {
+	return &ApiService{
+		requestHandler: requestHandler,
+		serviceURL:     apiURL + PATH,
+	}
+}
+
+// Get makes a GET request to definitions endpoint with *QueryParams and returns the exported Export.
+func (s *ApiService) Get(params *QueryParams) (*Export, error) {
+	data := url.Values{}
+	headers := make(map[string]interface{})
+
+	if params != nil {
+		for _, flow := range params.Flows {
+			data.Add("flow", flow)
+		}
+		for _, campaign := range params.Campaigns {
+			data.Add("campaign", campaign)
+		}
+		if params.Dependencies != "" {
+			data.Set("dependencies", params.Dependencies)
+		}
+	}
+
+	resp, err := s.requestHandler.Get(s.serviceURL, data, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Export{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Export represents the response of a request in definitions endpoint
+type Export struct {
+	Version   string            `json:"version,omitempty"`
+	Site      string            `json:"site,omitempty"`
+	Flows     []Flow            `json:"flows"`
+	Campaigns []json.RawMessage `json:"campaigns"`
+	Triggers  []json.RawMessage `json:"triggers"`
+	Fields    []Field           `json:"fields"`
+	Groups    []Group           `json:"groups"`
+}
+
+// Flow returns the exported flow with the given uuid, or nil if it isn't part of the export
+func (e *Export) Flow(uuid string) *Flow {
+	for i := range e.Flows {
+		if e.Flows[i].UUID == uuid {
+			return &e.Flows[i]
+		}
+	}
+	return nil
+}
+
+// Field represents a contact field exported as a dependency
+type Field struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Group represents a contact group exported as a dependency
+type Group struct {
+	UUID  string `json:"uuid"`
+	Name  string `json:"name"`
+	Query string `json:"query,omitempty"`
+}
+
+// QueryParams is a struct that represents the query parameters that can be passed in a request to definitions endpoint
+type QueryParams struct {
+	Flows        []string `json:"flow,omitempty"`
+	Campaigns    []string `json:"campaign,omitempty"`
+	Dependencies string   `json:"dependencies,omitempty"`
+}
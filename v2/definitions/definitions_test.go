@@ -0,0 +1,203 @@
+package definitions
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type DefinitionsTestCase struct {
+	Label        string
+	Method       string
+	QueryParams  *QueryParams
+	Status       int
+	ResponseBody string
+	FlowsCount   int
+	Error        error
+}
+
+var testCases = []DefinitionsTestCase{
+	{
+		Label:        "Test Get definitions",
+		Method:       "GET",
+		QueryParams:  &QueryParams{Flows: []string{"9ecc8e84-6b83-442b-a04a-8094d5de997b"}},
+		Status:       200,
+		ResponseBody: testData,
+		FlowsCount:   1,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Get definitions with status 400 error",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       400,
+		ResponseBody: `{"detail": "No flows or campaigns specified"}`,
+		Error: &client.RapidproRestError{
+			Status:  400,
+			Details: map[string]interface{}{"detail": "No flows or campaigns specified"},
+		},
+	},
+	{
+		Label:        "Test Get definitions with response error",
+		Method:       "GET",
+		Status:       200,
+		ResponseBody: "{",
+		Error:        errors.New("unexpected EOF"),
+	},
+}
+
+func TestDefinitions(t *testing.T) {
+	for _, tc := range testCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if tc.QueryParams != nil {
+					assert.Equal(t, tc.QueryParams.Flows, r.URL.Query()["flow"])
+				}
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			resp, err := service.Get(tc.QueryParams)
+			if err != nil {
+				assert.Equal(t, tc.Error.Error(), err.Error())
+			}
+			if tc.FlowsCount > 0 {
+				assert.Equal(t, tc.FlowsCount, len(resp.Flows))
+			}
+		})
+	}
+}
+
+func TestDefinitionsFlowModel(t *testing.T) {
+	export := &Export{}
+	assert.NoError(t, json.Unmarshal([]byte(testData), export))
+
+	assert.Equal(t, "13", export.Version)
+	assert.Nil(t, export.Flow("00000000-0000-0000-0000-000000000000"))
+	flow := export.Flow("9ecc8e84-6b83-442b-a04a-8094d5de997b")
+	assert.Equal(t, "Favorites", flow.Name)
+	assert.Equal(t, FlowTypeMessage, flow.Type)
+	assert.Equal(t, 2, len(flow.Nodes))
+	assert.Equal(t, []string{"¿Cuál es tu color favorito?"}, flow.Localization["spa"]["8eebd020-1af5-431c-b943-aa670fc74da9"]["text"])
+
+	ask := flow.Node("10c9c241-777f-4010-a841-6e87abed8520")
+	assert.Equal(t, ActionTypeSendMsg, ask.Actions[0].Type)
+	assert.Equal(t, "What is your favorite color?", ask.Actions[0].Text)
+	assert.Equal(t, "event", ask.Actions[0].Topic.Name)
+	assert.Equal(t, RouterTypeSwitch, ask.Router.Type)
+	assert.Equal(t, "msg", ask.Router.Wait.Type)
+	assert.Equal(t, "has_any_word", ask.Router.Cases[0].Type)
+	assert.Equal(t, "Red", ask.Router.Category(ask.Router.Cases[0].CategoryUUID).Name)
+	assert.Nil(t, ask.Router.Category("missing"))
+	assert.Equal(t, "Color", ask.Router.ResultName)
+
+	exit := ask.Exit("e4a4c9d4-4d4c-4e55-9d6c-2cd2c5fa9a84")
+	assert.Equal(t, "a1e649db-91e0-47c4-ab14-eba0d1475116", exit.DestinationUUID)
+	assert.Nil(t, ask.Exit("missing"))
+
+	ticket := flow.Node("a1e649db-91e0-47c4-ab14-eba0d1475116")
+	assert.Equal(t, ActionTypeOpenTicket, ticket.Actions[0].Type)
+	assert.Equal(t, "Support", ticket.Actions[0].Topic.Name)
+	assert.Equal(t, "f9a3e4b8-7d3c-4ef2-bf40-b6e9c8c0bc2b", ticket.Actions[0].Topic.UUID)
+
+	assert.Equal(t, []Field{{Key: "color", Name: "Color", Type: "text"}}, export.Fields)
+
+	// exports survive a round trip so they can be version-controlled
+	encoded, err := json.Marshal(export)
+	assert.NoError(t, err)
+	decoded := &Export{}
+	assert.NoError(t, json.Unmarshal(encoded, decoded))
+	assert.Equal(t, export, decoded)
+}
+
+func TestResultKey(t *testing.T) {
+	assert.Equal(t, "favorite_color", ResultKey("Favorite Color"))
+	assert.Equal(t, "color", ResultKey(" Color! "))
+}
+
+var testData = `{
+	"version": "13",
+	"site": "https://app.rapidpro.io",
+	"flows": [
+		{
+			"uuid": "9ecc8e84-6b83-442b-a04a-8094d5de997b",
+			"name": "Favorites",
+			"spec_version": "13.1.0",
+			"language": "eng",
+			"type": "messaging",
+			"revision": 12,
+			"expire_after_minutes": 10080,
+			"localization": {
+				"spa": {
+					"8eebd020-1af5-431c-b943-aa670fc74da9": {"text": ["¿Cuál es tu color favorito?"]}
+				}
+			},
+			"nodes": [
+				{
+					"uuid": "10c9c241-777f-4010-a841-6e87abed8520",
+					"actions": [
+						{
+							"uuid": "8eebd020-1af5-431c-b943-aa670fc74da9",
+							"type": "send_msg",
+							"text": "What is your favorite color?",
+							"quick_replies": ["Red", "Green"],
+							"topic": "event"
+						}
+					],
+					"router": {
+						"type": "switch",
+						"operand": "@input.text",
+						"wait": {"type": "msg", "timeout": {"seconds": 300, "category_uuid": "f5d1a4b0-2b8a-4d4e-9f5c-9a1e2e3f4a5b"}},
+						"cases": [
+							{"uuid": "98503572-25bf-40ce-ad72-8836b6549a38", "type": "has_any_word", "arguments": ["red"], "category_uuid": "5b5f8a4e-3c8b-4a3b-8f2a-1e2f3a4b5c6d"}
+						],
+						"categories": [
+							{"uuid": "5b5f8a4e-3c8b-4a3b-8f2a-1e2f3a4b5c6d", "name": "Red", "exit_uuid": "e4a4c9d4-4d4c-4e55-9d6c-2cd2c5fa9a84"},
+							{"uuid": "b4f6c2d0-1a2b-4c3d-8e4f-5a6b7c8d9e0f", "name": "Other", "exit_uuid": "3f8c2b1a-9d8e-4f7a-b6c5-d4e3f2a1b0c9"},
+							{"uuid": "f5d1a4b0-2b8a-4d4e-9f5c-9a1e2e3f4a5b", "name": "No Response", "exit_uuid": "7a6b5c4d-3e2f-4a1b-9c8d-7e6f5a4b3c2d"}
+						],
+						"default_category_uuid": "b4f6c2d0-1a2b-4c3d-8e4f-5a6b7c8d9e0f",
+						"result_name": "Color"
+					},
+					"exits": [
+						{"uuid": "e4a4c9d4-4d4c-4e55-9d6c-2cd2c5fa9a84", "destination_uuid": "a1e649db-91e0-47c4-ab14-eba0d1475116"},
+						{"uuid": "3f8c2b1a-9d8e-4f7a-b6c5-d4e3f2a1b0c9", "destination_uuid": "10c9c241-777f-4010-a841-6e87abed8520"},
+						{"uuid": "7a6b5c4d-3e2f-4a1b-9c8d-7e6f5a4b3c2d"}
+					]
+				},
+				{
+					"uuid": "a1e649db-91e0-47c4-ab14-eba0d1475116",
+					"actions": [
+						{
+							"uuid": "c2d3e4f5-a6b7-4c8d-9e0f-1a2b3c4d5e6f",
+							"type": "open_ticket",
+							"ticketer": {"uuid": "d7e8f9a0-b1c2-4d3e-8f4a-5b6c7d8e9f0a", "name": "Email"},
+							"topic": {"uuid": "f9a3e4b8-7d3c-4ef2-bf40-b6e9c8c0bc2b", "name": "Support"},
+							"body": "@results.color",
+							"result_name": "Ticket"
+						}
+					],
+					"exits": [{"uuid": "0b1c2d3e-4f5a-4b6c-8d7e-9f0a1b2c3d4e"}]
+				}
+			],
+			"_ui": {"nodes":{}}
+		}
+	],
+	"campaigns": [],
+	"triggers": [],
+	"fields": [{"key": "color", "name": "Color", "type": "text"}],
+	"groups": []
+}`
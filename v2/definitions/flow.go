@@ -0,0 +1,135 @@
+package definitions
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// Flow types
+const (
+	FlowTypeMessage    = "messaging"
+	FlowTypeBackground = "messaging_background"
+	FlowTypeOffline    = "messaging_offline"
+	FlowTypeVoice      = "voice"
+)
+
+// Router types
+const (
+	RouterTypeSwitch = "switch"
+	RouterTypeRandom = "random"
+)
+
+// Flow represents an exported flow definition
+type Flow struct {
+	UUID               string          `json:"uuid"`
+	Name               string          `json:"name"`
+	SpecVersion        string          `json:"spec_version"`
+	Language           string          `json:"language"`
+	Type               string          `json:"type"`
+	Revision           int             `json:"revision"`
+	ExpireAfterMinutes int             `json:"expire_after_minutes"`
+	Localization       Localization    `json:"localization,omitempty"`
+	Nodes              []Node          `json:"nodes"`
+	UI                 json.RawMessage `json:"_ui,omitempty"`
+}
+
+// Node returns the node with the given uuid, or nil if the flow has no such node
+func (f *Flow) Node(uuid string) *Node {
+	for i := range f.Nodes {
+		if f.Nodes[i].UUID == uuid {
+			return &f.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// Localization maps a language to the translated properties of each localizable item by uuid
+type Localization map[string]map[string]map[string][]string
+
+// Node represents a flow node, a list of actions followed by an optional router
+type Node struct {
+	UUID    string   `json:"uuid"`
+	Actions []Action `json:"actions"`
+	Router  *Router  `json:"router,omitempty"`
+	Exits   []Exit   `json:"exits"`
+}
+
+// Exit returns the exit with the given uuid, or nil if the node has no such exit
+func (n *Node) Exit(uuid string) *Exit {
+	for i := range n.Exits {
+		if n.Exits[i].UUID == uuid {
+			return &n.Exits[i]
+		}
+	}
+	return nil
+}
+
+// Exit represents a node exit, a nil destination ends the flow
+type Exit struct {
+	UUID            string `json:"uuid"`
+	DestinationUUID string `json:"destination_uuid,omitempty"`
+}
+
+// Router represents how a node picks one of its exits
+type Router struct {
+	Type                string     `json:"type"`
+	Operand             string     `json:"operand,omitempty"`
+	Cases               []Case     `json:"cases,omitempty"`
+	Categories          []Category `json:"categories"`
+	DefaultCategoryUUID string     `json:"default_category_uuid,omitempty"`
+	ResultName          string     `json:"result_name,omitempty"`
+	Wait                *Wait      `json:"wait,omitempty"`
+}
+
+// Category returns the category with the given uuid, or nil if the router has no such category
+func (r *Router) Category(uuid string) *Category {
+	for i := range r.Categories {
+		if r.Categories[i].UUID == uuid {
+			return &r.Categories[i]
+		}
+	}
+	return nil
+}
+
+var nonWordRegex = regexp.MustCompile(`[^\p{L}\p{N}_]+`)
+
+// ResultKey returns the key a result name is saved under, e.g. favorite_color for "Favorite Color"
+func ResultKey(name string) string {
+	return strings.Trim(nonWordRegex.ReplaceAllString(strings.ToLower(name), "_"), "_")
+}
+
+// Case represents a test of a switch router
+type Case struct {
+	UUID         string   `json:"uuid"`
+	Type         string   `json:"type"`
+	Arguments    []string `json:"arguments,omitempty"`
+	CategoryUUID string   `json:"category_uuid"`
+}
+
+// Category represents a router category and the exit it leads to
+type Category struct {
+	UUID     string `json:"uuid"`
+	Name     string `json:"name"`
+	ExitUUID string `json:"exit_uuid"`
+}
+
+// Wait represents a router waiting for contact input
+type Wait struct {
+	Type    string   `json:"type"`
+	Timeout *Timeout `json:"timeout,omitempty"`
+	Hint    *Hint    `json:"hint,omitempty"`
+}
+
+// Timeout represents how long a wait lasts and the category taken when it expires
+type Timeout struct {
+	Seconds      int    `json:"seconds"`
+	CategoryUUID string `json:"category_uuid"`
+}
+
+// Hint represents the kind of input a wait expects
+type Hint struct {
+	Type         string `json:"type"`
+	Count        int    `json:"count,omitempty"`
+	TerminatedBy string `json:"terminated_by,omitempty"`
+}
@@ -3,6 +3,9 @@ package flows
 import (
 	"encoding/json"
 	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	rapidpro "github.com/rasoro/rapidpro-api-go/client"
@@ -10,6 +13,14 @@ import (
 
 const PATH = "/v2/flows.json"
 
+// Flow types
+const (
+	TypeMessage    = "message"
+	TypeVoice      = "voice"
+	TypeBackground = "background"
+	TypeSurveyor   = "survey"
+)
+
 type ApiService struct {
 	serviceURL     string
 	requestHandler *rapidpro.RequestHandler
@@ -37,6 +48,15 @@ func (s *ApiService) Get(params *QueryParams) (*Response, error) {
 		if params.Before != nil {
 			data.Set("before", params.Before.Format(time.RFC3339))
 		}
+		if params.Type != "" {
+			data.Set("type", params.Type)
+		}
+		if params.Archived != nil {
+			data.Set("archived", strconv.FormatBool(*params.Archived))
+		}
+		if params.Cursor != "" {
+			data.Set("cursor", params.Cursor)
+		}
 	}
 
 	resp, err := s.requestHandler.Get(s.serviceURL, data, headers)
@@ -50,17 +70,28 @@ func (s *ApiService) Get(params *QueryParams) (*Response, error) {
 	if err := json.NewDecoder(resp.Body).Decode(flowResponse); err != nil {
 		return nil, err
 	}
+
+	// label and name aren't supported by the endpoint so they are applied to the returned page
+	if params != nil && (params.Label != "" || params.NamePattern != nil) {
+		results := make([]Flow, 0, len(flowResponse.Results))
+		for i := range flowResponse.Results {
+			if params.Match(&flowResponse.Results[i]) {
+				results = append(results, flowResponse.Results[i])
+			}
+		}
+		flowResponse.Results = results
+	}
 	return flowResponse, err
 }
 
 // Flow is a struct that represents a flow object
 type Flow struct {
-	UUID     string        `json:"uuid"`
-	Name     string        `json:"name"`
-	Type     string        `json:"type"`
-	Archived bool          `json:"archived"`
-	Labels   []interface{} `json:"labels"`
-	Expires  int           `json:"expires"`
+	UUID     string      `json:"uuid"`
+	Name     string      `json:"name"`
+	Type     string      `json:"type"`
+	Archived bool        `json:"archived"`
+	Labels   []Reference `json:"labels"`
+	Expires  int         `json:"expires"`
 	Runs     struct {
 		Active      int `json:"active"`
 		Completed   int `json:"completed"`
@@ -73,9 +104,15 @@ type Flow struct {
 		Categories []string `json:"categories"`
 		NodeUUIDS  []string `json:"node_uuids"`
 	} `json:"results"`
-	ParentRefs []interface{} `json:"parent_refs"`
-	CreatedOn  time.Time     `json:"created_on"`
-	ModifiedOn time.Time     `json:"modified_on"`
+	ParentRefs []Reference `json:"parent_refs"`
+	CreatedOn  time.Time   `json:"created_on"`
+	ModifiedOn time.Time   `json:"modified_on"`
+}
+
+// Reference is a struct that represents a reference to a label or flow
+type Reference struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
 }
 
 // Response is a struct that represents the response of a request in flows endpoint
@@ -85,9 +122,58 @@ type Response struct {
 	Results  []Flow      `json:"results"`
 }
 
-// QueryParams is a struct that represents the query parameters that can be passed in a request to flows endpoint
+// NextCursor returns the cursor to pass in QueryParams to get the next page, or an empty string on the last page
+func (r *Response) NextCursor() string {
+	return rapidpro.Cursor(r.Next)
+}
+
+// QueryParams is a struct that represents the query parameters that can be passed in a request to flows endpoint.
+// Label and NamePattern aren't supported by the endpoint and are applied to each returned page instead,
+// so a page may have fewer results than the page size while still having a next page.
 type QueryParams struct {
-	UUID   string     `json:"uuid"`
-	After  *time.Time `json:"after"`
-	Before *time.Time `json:"before"`
+	UUID        string         `json:"uuid"`
+	After       *time.Time     `json:"after"`
+	Before      *time.Time     `json:"before"`
+	Type        string         `json:"type"`
+	Archived    *bool          `json:"archived"`
+	Cursor      string         `json:"cursor"`
+	Label       string         `json:"-"`
+	NamePattern *regexp.Regexp `json:"-"`
+}
+
+// Match returns whether a flow passes all the filters of the QueryParams, it's used to filter
+// on the client what the endpoint can't and can be used to query flows that were already fetched
+func (p *QueryParams) Match(flow *Flow) bool {
+	if p.UUID != "" && flow.UUID != p.UUID {
+		return false
+	}
+	if p.After != nil && flow.ModifiedOn.Before(*p.After) {
+		return false
+	}
+	if p.Before != nil && flow.ModifiedOn.After(*p.Before) {
+		return false
+	}
+	if p.Type != "" && flow.Type != p.Type {
+		return false
+	}
+	if p.Archived != nil && flow.Archived != *p.Archived {
+		return false
+	}
+	if p.Label != "" && !flow.HasLabel(p.Label) {
+		return false
+	}
+	if p.NamePattern != nil && !p.NamePattern.MatchString(flow.Name) {
+		return false
+	}
+	return true
+}
+
+// HasLabel returns whether the flow has a label with the given uuid or name, names are compared ignoring case
+func (f *Flow) HasLabel(label string) bool {
+	for _, l := range f.Labels {
+		if l.UUID == label || strings.EqualFold(l.Name, label) {
+			return true
+		}
+	}
+	return false
 }
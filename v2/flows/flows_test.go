@@ -3,6 +3,7 @@ package flows
 import (
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"testing"
 	"time"
 
@@ -78,6 +79,99 @@ func TestFlows(t *testing.T) {
 	}
 }
 
+func TestFlowsFilters(t *testing.T) {
+	archived := false
+	params := &QueryParams{
+		Type:        TypeMessage,
+		Archived:    &archived,
+		Label:       "important",
+		NamePattern: regexp.MustCompile(`^Survey\d$`),
+	}
+
+	mockServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "message", r.URL.Query().Get("type"))
+			assert.Equal(t, "false", r.URL.Query().Get("archived"))
+			assert.Empty(t, r.URL.Query().Get("label"))
+			_, _ = w.Write([]byte(testDataFilters))
+		}))
+	defer mockServer.Close()
+
+	defaultClient := &client.Client{
+		Credentials: &client.Credentials{Token: "token123"},
+	}
+	service := NewService(client.NewRequestHandler(defaultClient), mockServer.URL)
+	resp, err := service.Get(params)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resp.Results))
+	assert.Equal(t, "Survey1", resp.Results[0].Name)
+	assert.Equal(t, "Important", resp.Results[0].Labels[0].Name)
+	assert.Equal(t, "Parent", resp.Results[0].ParentRefs[0].Name)
+	assert.Equal(t, "", resp.NextCursor())
+}
+
+func TestFlowsMatch(t *testing.T) {
+	archived := true
+	flow := &Flow{
+		UUID:       uuid,
+		Name:       "Registration",
+		Type:       TypeVoice,
+		Archived:   true,
+		Labels:     []Reference{{UUID: "5a4eb79e-1b1f-4ae3-8700-09384cca385f", Name: "Important"}},
+		ModifiedOn: time.Date(2016, time.January, 8, 0, 0, 0, 0, time.UTC),
+	}
+
+	assert.True(t, (&QueryParams{}).Match(flow))
+	assert.True(t, (&QueryParams{UUID: uuid, After: &after, Before: &before, Type: TypeVoice, Archived: &archived}).Match(flow))
+	assert.True(t, (&QueryParams{Label: "5a4eb79e-1b1f-4ae3-8700-09384cca385f", NamePattern: regexp.MustCompile("(?i)^reg")}).Match(flow))
+	assert.False(t, (&QueryParams{UUID: "other"}).Match(flow))
+	assert.False(t, (&QueryParams{After: &before}).Match(flow))
+	assert.False(t, (&QueryParams{Before: &after}).Match(flow))
+	assert.False(t, (&QueryParams{Type: TypeSurveyor}).Match(flow))
+	assert.False(t, (&QueryParams{Archived: new(bool)}).Match(flow))
+	assert.False(t, (&QueryParams{Label: "Other"}).Match(flow))
+	assert.False(t, (&QueryParams{NamePattern: regexp.MustCompile("^Survey")}).Match(flow))
+}
+
+var testDataFilters = `
+{
+	"next": null,
+	"previous": null,
+	"results": [
+		{
+			"uuid": "5f05311e-8f81-4a67-a5b5-1501b6d6496a",
+			"name": "Survey1",
+			"type": "message",
+			"archived": false,
+			"labels": [{"name": "Important", "uuid": "5a4eb79e-1b1f-4ae3-8700-09384cca385f"}],
+			"parent_refs": [{"uuid": "ec6f2bde-50fa-4589-a93b-c8c9eba93c58", "name": "Parent"}],
+			"created_on": "2016-01-06T15:33:00.813162Z",
+			"modified_on": "2017-01-07T13:14:00.453567Z"
+		},
+		{
+			"uuid": "9d9dba87-6e91-4e08-85db-fabeadffac02",
+			"name": "Survey2",
+			"type": "message",
+			"archived": false,
+			"labels": [],
+			"parent_refs": [],
+			"created_on": "2016-01-06T15:33:00.813162Z",
+			"modified_on": "2017-01-07T13:14:00.453567Z"
+		},
+		{
+			"uuid": "9d9dba87-6e91-4e08-85db-fabeadffac03",
+			"name": "Survey Copy",
+			"type": "message",
+			"archived": false,
+			"labels": [{"name": "Important", "uuid": "5a4eb79e-1b1f-4ae3-8700-09384cca385f"}],
+			"parent_refs": [],
+			"created_on": "2016-01-06T15:33:00.813162Z",
+			"modified_on": "2017-01-07T13:14:00.453567Z"
+		}
+	]
+}
+`
+
 var testData = `
 {
 	"next": null,
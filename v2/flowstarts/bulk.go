@@ -0,0 +1,240 @@
+package flowstarts
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+// MaxBatchSize is the maximum number of contacts, and of urns, RapidPro accepts in a single flow start
+const MaxBatchSize = 100
+
+// BulkOptions configures how BulkPost splits and submits flow starts
+type BulkOptions struct {
+	// BatchSize is the number of recipients per start, zero or anything above MaxBatchSize means MaxBatchSize
+	BatchSize int
+	// Concurrency is the number of starts submitted at the same time, zero means 1
+	Concurrency int
+	// Interval is the minimum time between two requests across all workers, zero disables throttling
+	Interval time.Duration
+	// MaxRetries is the number of times a throttled (429) request is retried
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry, doubled after each attempt
+	RetryBackoff time.Duration
+}
+
+// DefaultBulkOptions are used when BulkPost is called with nil options, the interval keeps
+// submissions under the 2500 requests per hour RapidPro allows by default
+var DefaultBulkOptions = BulkOptions{
+	BatchSize:    MaxBatchSize,
+	Concurrency:  2,
+	Interval:     time.Hour / 2500,
+	MaxRetries:   3,
+	RetryBackoff: 5 * time.Second,
+}
+
+// BatchResult is the outcome of submitting one batch of a bulk start
+type BatchResult struct {
+	Index     int
+	Groups    []string
+	Contacts  []string
+	URNs      []string
+	FlowStart *FlowStart
+	Err       error
+}
+
+// BulkReport maps each batch of a bulk start to the FlowStart it created or the error that prevented it
+type BulkReport struct {
+	Flow    string
+	Batches []BatchResult
+}
+
+// Failed returns the batches that could not be submitted
+func (r *BulkReport) Failed() []BatchResult {
+	var failed []BatchResult
+	for _, batch := range r.Batches {
+		if batch.Err != nil {
+			failed = append(failed, batch)
+		}
+	}
+	return failed
+}
+
+// Remaining returns a body with the recipients of the failed batches so they can be resubmitted with BulkPost,
+// groups are only included if the first batch, which is the one that carries them, failed
+func (r *BulkReport) Remaining(body PostBody) PostBody {
+	remaining := body
+	remaining.Groups = nil
+	remaining.Contacts = nil
+	remaining.URNs = nil
+	for _, batch := range r.Failed() {
+		remaining.Groups = append(remaining.Groups, batch.Groups...)
+		remaining.Contacts = append(remaining.Contacts, batch.Contacts...)
+		remaining.URNs = append(remaining.URNs, batch.URNs...)
+	}
+	return remaining
+}
+
+// BulkPost starts a flow for an arbitrarily large list of contacts and urns by de-duplicating them and splitting
+// them into starts RapidPro accepts. Groups are sent with the first batch. Every batch is attempted even if others
+// fail, the returned report records the outcome of each one and the error is the first failure, if any.
+func (s *ApiService) BulkPost(ctx context.Context, body PostBody, opts *BulkOptions) (*BulkReport, error) {
+	if opts == nil {
+		opts = &DefaultBulkOptions
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 || batchSize > MaxBatchSize {
+		batchSize = MaxBatchSize
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	report := &BulkReport{Flow: body.Flow}
+	for _, contacts := range split(dedupe(body.Contacts, strings.TrimSpace), batchSize) {
+		report.Batches = append(report.Batches, BatchResult{Index: len(report.Batches), Contacts: contacts})
+	}
+	for _, urns := range split(dedupe(body.URNs, normalizeURN), batchSize) {
+		report.Batches = append(report.Batches, BatchResult{Index: len(report.Batches), URNs: urns})
+	}
+	if len(report.Batches) == 0 && len(body.Groups) > 0 {
+		report.Batches = append(report.Batches, BatchResult{})
+	}
+	if len(report.Batches) > 0 {
+		report.Batches[0].Groups = body.Groups
+	}
+
+	limiter := &limiter{interval: opts.Interval}
+	jobs := make(chan int)
+	wg := sync.WaitGroup{}
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				batch := &report.Batches[index]
+				batchBody := body
+				batchBody.Contacts = batch.Contacts
+				batchBody.URNs = batch.URNs
+				batchBody.Groups = batch.Groups
+				batch.FlowStart, batch.Err = s.postWithRetries(ctx, limiter, batchBody, opts)
+			}
+		}()
+	}
+
+	for index := range report.Batches {
+		jobs <- index
+	}
+	close(jobs)
+	wg.Wait()
+
+	if failed := report.Failed(); len(failed) > 0 {
+		return report, errors.Wrapf(failed[0].Err, "%d of %d batches failed", len(failed), len(report.Batches))
+	}
+	return report, nil
+}
+
+func (s *ApiService) postWithRetries(ctx context.Context, limiter *limiter, body PostBody, opts *BulkOptions) (*FlowStart, error) {
+	backoff := opts.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		if err := limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+		flowStart, err := s.Post(body)
+		if err == nil || attempt >= opts.MaxRetries || !isThrottled(err) {
+			return flowStart, err
+		}
+		if err := sleep(ctx, backoff); err != nil {
+			return nil, err
+		}
+		backoff *= 2
+	}
+}
+
+func isThrottled(err error) bool {
+	restErr, ok := err.(*rapidpro.RapidproRestError)
+	return ok && restErr.Status == http.StatusTooManyRequests
+}
+
+// limiter spaces requests shared by several workers at least interval apart
+type limiter struct {
+	interval time.Duration
+	mutex    sync.Mutex
+	next     time.Time
+}
+
+func (l *limiter) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if l.interval <= 0 {
+		return nil
+	}
+
+	l.mutex.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mutex.Unlock()
+
+	return sleep(ctx, at.Sub(now))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func dedupe(values []string, normalize func(string) string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		key := normalize(value)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, strings.TrimSpace(value))
+	}
+	return unique
+}
+
+// normalizeURN makes urns that only differ by scheme case or surrounding whitespace equal
+func normalizeURN(urn string) string {
+	urn = strings.TrimSpace(urn)
+	if i := strings.Index(urn, ":"); i > 0 {
+		return strings.ToLower(urn[:i]) + urn[i:]
+	}
+	return urn
+}
+
+func split(values []string, size int) [][]string {
+	var batches [][]string
+	for len(values) > 0 {
+		n := size
+		if len(values) < n {
+			n = len(values)
+		}
+		batches = append(batches, values[:n])
+		values = values[n:]
+	}
+	return batches
+}
@@ -0,0 +1,181 @@
+package flowstarts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type bulkServer struct {
+	mutex     sync.Mutex
+	bodies    []PostBody
+	throttled int
+	failURN   string
+}
+
+func (b *bulkServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body := PostBody{}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.throttled > 0 {
+		b.throttled--
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"detail": "Request was throttled."}`))
+		return
+	}
+	for _, urn := range body.URNs {
+		if urn == b.failURN {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"urns": ["Invalid URN: ` + urn + `"]}`))
+			return
+		}
+	}
+	b.bodies = append(b.bodies, body)
+	fmt.Fprintf(w, `{"uuid": "start-%d", "status": "pending"}`, len(b.bodies))
+}
+
+func newBulkService(handler http.Handler) (*ApiService, func()) {
+	server := httptest.NewServer(handler)
+	defaultClient := &client.Client{
+		Credentials: &client.Credentials{Token: "token123"},
+	}
+	return NewService(client.NewRequestHandler(defaultClient), server.URL), server.Close
+}
+
+func recipients(prefix string, n int) []string {
+	values := make([]string, n)
+	for i := range values {
+		values[i] = fmt.Sprintf("%s%d", prefix, i)
+	}
+	return values
+}
+
+func TestBulkPost(t *testing.T) {
+	server := &bulkServer{}
+	service, closeServer := newBulkService(server)
+	defer closeServer()
+
+	contacts := append(recipients("contact-", 250), "contact-0", " contact-1 ")
+	urns := append(recipients("tel:+2507880", 30), "TEL:+25078800")
+	body := PostBody{Flow: "f5901b62-ba76-4003-9c62-72fdacc1b7b7", Groups: []string{"group-1"}, Contacts: contacts, URNs: urns}
+
+	report, err := service.BulkPost(context.Background(), body, &BulkOptions{Concurrency: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(report.Batches))
+	assert.Empty(t, report.Failed())
+
+	sizes := []int{100, 100, 50, 30}
+	starts := map[string]bool{}
+	for i, batch := range report.Batches {
+		assert.Equal(t, i, batch.Index)
+		assert.Equal(t, sizes[i], len(batch.Contacts)+len(batch.URNs))
+		assert.Equal(t, "pending", batch.FlowStart.Status)
+		starts[batch.FlowStart.UUID] = true
+	}
+	assert.Equal(t, 4, len(starts))
+
+	submitted := 0
+	groups := 0
+	for _, body := range server.bodies {
+		submitted += len(body.Contacts) + len(body.URNs)
+		groups += len(body.Groups)
+	}
+	assert.Equal(t, 280, submitted)
+	assert.Equal(t, 1, groups)
+}
+
+func TestBulkPostOnlyGroups(t *testing.T) {
+	server := &bulkServer{}
+	service, closeServer := newBulkService(server)
+	defer closeServer()
+
+	report, err := service.BulkPost(context.Background(), PostBody{Flow: "flow", Groups: []string{"group-1"}}, &BulkOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(report.Batches))
+	assert.Equal(t, []string{"group-1"}, server.bodies[0].Groups)
+}
+
+func TestBulkPostRetriesThrottled(t *testing.T) {
+	server := &bulkServer{throttled: 2}
+	service, closeServer := newBulkService(server)
+	defer closeServer()
+
+	opts := &BulkOptions{Interval: time.Millisecond, MaxRetries: 2, RetryBackoff: time.Millisecond}
+	report, err := service.BulkPost(context.Background(), PostBody{Flow: "flow", URNs: recipients("tel:+1", 10)}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "start-1", report.Batches[0].FlowStart.UUID)
+
+	server.throttled = 1
+	opts.MaxRetries = 0
+	_, err = service.BulkPost(context.Background(), PostBody{Flow: "flow", URNs: recipients("tel:+1", 10)}, opts)
+	assert.Equal(t, http.StatusTooManyRequests, errors.Cause(err).(*client.RapidproRestError).Status)
+}
+
+func TestBulkPostPartialFailure(t *testing.T) {
+	server := &bulkServer{failURN: "tel:+11150"}
+	service, closeServer := newBulkService(server)
+	defer closeServer()
+
+	body := PostBody{Flow: "flow", Groups: []string{"group-1"}, URNs: recipients("tel:+11", 300)}
+	report, err := service.BulkPost(context.Background(), body, &BulkOptions{Concurrency: 2})
+	assert.EqualError(t, err, `1 of 3 batches failed: Status: 400 - Error: {"urns":["Invalid URN: tel:+11150"]}`)
+	assert.Equal(t, 1, len(report.Failed()))
+	assert.Equal(t, 1, report.Failed()[0].Index)
+
+	remaining := report.Remaining(body)
+	assert.Equal(t, "flow", remaining.Flow)
+	assert.Nil(t, remaining.Groups)
+	assert.Equal(t, report.Batches[1].URNs, remaining.URNs)
+
+	server.failURN = ""
+	report, err = service.BulkPost(context.Background(), remaining, &BulkOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(report.Batches))
+}
+
+func TestBulkPostFirstBatchFailure(t *testing.T) {
+	server := &bulkServer{failURN: "tel:+1150"}
+	service, closeServer := newBulkService(server)
+	defer closeServer()
+
+	body := PostBody{Flow: "flow", Groups: []string{"group-1"}, URNs: recipients("tel:+11", 150)}
+	report, err := service.BulkPost(context.Background(), body, &BulkOptions{})
+	assert.EqualError(t, err, `1 of 2 batches failed: Status: 400 - Error: {"urns":["Invalid URN: tel:+1150"]}`)
+	assert.Equal(t, 0, report.Failed()[0].Index)
+	assert.Equal(t, []string{"group-1"}, report.Failed()[0].Groups)
+	assert.Nil(t, server.bodies[0].Groups)
+
+	remaining := report.Remaining(body)
+	assert.Equal(t, []string{"group-1"}, remaining.Groups)
+	assert.Equal(t, 100, len(remaining.URNs))
+
+	server.failURN = ""
+	report, err = service.BulkPost(context.Background(), remaining, &BulkOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(report.Batches))
+	assert.Equal(t, []string{"group-1"}, server.bodies[1].Groups)
+}
+
+func TestBulkPostCancelled(t *testing.T) {
+	server := &bulkServer{}
+	service, closeServer := newBulkService(server)
+	defer closeServer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report, err := service.BulkPost(ctx, PostBody{Flow: "flow", URNs: recipients("tel:+1", 150)}, nil)
+	assert.Equal(t, context.Canceled, errors.Cause(err))
+	assert.Equal(t, 2, len(report.Failed()))
+	assert.Empty(t, server.bodies)
+}
@@ -10,6 +10,15 @@ import (
 
 const PATH = "/v2/flow_starts.json"
 
+// Flow start statuses
+const (
+	StatusPending     = "pending"
+	StatusStarting    = "starting"
+	StatusComplete    = "complete"
+	StatusFailed      = "failed"
+	StatusInterrupted = "interrupted"
+)
+
 type ApiService struct {
 	URL            string
 	requestHandler *rapidpro.RequestHandler
@@ -30,12 +39,18 @@ func (s *ApiService) Get(params *QueryParams) (*Response, error) {
 		if params.ID != "" {
 			data.Set("id", params.ID)
 		}
+		if params.UUID != "" {
+			data.Set("uuid", params.UUID)
+		}
 		if params.After != nil {
 			data.Set("after", params.After.Format(time.RFC3339))
 		}
 		if params.Before != nil {
 			data.Set("before", params.Before.Format(time.RFC3339))
 		}
+		if params.Cursor != "" {
+			data.Set("cursor", params.Cursor)
+		}
 	}
 
 	resp, err := s.requestHandler.Get(s.URL, data, headers)
@@ -80,15 +95,20 @@ type FlowStart struct {
 		UUID string `json:"uuid,omitempty"`
 		Name string `json:"name,omitempty"`
 	} `json:"contacts,omitempty"`
-	RestartParticipants bool   `json:"restart_participants,omitempty"`
-	ExcludeActive       bool   `json:"exclude_active,omitempty"`
-	Status              string `json:"status,omitempty"`
-	Params              struct {
-		FirstName string `json:"first_name,omitempty"`
-		LastName  string `json:"last_name,omitempty"`
-	} `json:"params,omitempty"`
-	CreatedOn  *time.Time `json:"created_on,omitempty"`
-	ModifiedOn *time.Time `json:"modified_on,omitempty"`
+	RestartParticipants bool            `json:"restart_participants,omitempty"`
+	ExcludeActive       bool            `json:"exclude_active,omitempty"`
+	Status              string          `json:"status,omitempty"`
+	Params              json.RawMessage `json:"params,omitempty"`
+	CreatedOn           *time.Time      `json:"created_on,omitempty"`
+	ModifiedOn          *time.Time      `json:"modified_on,omitempty"`
+}
+
+// DecodeParams decodes the params of the flow start into v, which is left untouched if the start has no params
+func (f *FlowStart) DecodeParams(v interface{}) error {
+	if len(f.Params) == 0 || string(f.Params) == "null" {
+		return nil
+	}
+	return json.Unmarshal(f.Params, v)
 }
 
 type Response struct {
@@ -97,16 +117,38 @@ type Response struct {
 	Results  []FlowStart `json:"results"`
 }
 
+// NextCursor returns the cursor to pass in QueryParams to get the next page, or an empty string on the last page
+func (r *Response) NextCursor() string {
+	return rapidpro.Cursor(r.Next)
+}
+
 type QueryParams struct {
 	ID     string     `json:"id,omitempty"`
+	UUID   string     `json:"uuid,omitempty"`
 	After  *time.Time `json:"after,omitempty"`
 	Before *time.Time `json:"before,omitempty"`
+	Cursor string     `json:"cursor,omitempty"`
 }
 
+// PostBody represents the body of a request to create a flow start. Params is any JSON object, available
+// in the flow as @trigger.params, and RestartParticipants is a pointer since RapidPro restarts participants
+// unless it is explicitly false.
 type PostBody struct {
-	Flow     string             `json:"flow,omitempty"`
-	Groups   []string           `json:"groups,omitempty"`
-	Contacts []string           `json:"contacts,omitempty"`
-	URNs     []string           `json:"urns,omitempty"`
-	Params   *map[string]string `json:"params,omitempty"`
+	Flow                string          `json:"flow,omitempty"`
+	Groups              []string        `json:"groups,omitempty"`
+	Contacts            []string        `json:"contacts,omitempty"`
+	URNs                []string        `json:"urns,omitempty"`
+	Params              json.RawMessage `json:"params,omitempty"`
+	RestartParticipants *bool           `json:"restart_participants,omitempty"`
+	ExcludeActive       bool            `json:"exclude_active,omitempty"`
+}
+
+// SetParams marshals v, usually a struct mapped through its json tags, into the params of the flow start
+func (b *PostBody) SetParams(v interface{}) error {
+	params, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b.Params = params
+	return nil
 }
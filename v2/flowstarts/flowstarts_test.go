@@ -136,6 +136,84 @@ func TestFlowStarts(t *testing.T) {
 	}
 }
 
+type surveyParams struct {
+	FirstName string `json:"first_name"`
+	Visits    int    `json:"visits"`
+	Clinic    struct {
+		Name     string   `json:"name"`
+		Services []string `json:"services"`
+	} `json:"clinic"`
+}
+
+func TestFlowStartsParams(t *testing.T) {
+	restart := false
+	mockServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body := map[string]interface{}{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, false, body["restart_participants"])
+			assert.Equal(t, true, body["exclude_active"])
+			params := body["params"].(map[string]interface{})
+			assert.Equal(t, float64(3), params["visits"])
+			assert.Equal(t, "Kigali", params["clinic"].(map[string]interface{})["name"])
+
+			response, _ := json.Marshal(map[string]interface{}{"uuid": "6846356a-b25b-4a2c-b999-b55c53880dd0", "status": "pending", "params": params})
+			w.Write(response)
+		}))
+	defer mockServer.Close()
+
+	defaultClient := &client.Client{
+		Credentials: &client.Credentials{Token: "token123"},
+	}
+	service := NewService(client.NewRequestHandler(defaultClient), mockServer.URL)
+
+	params := surveyParams{FirstName: "Ryan", Visits: 3}
+	params.Clinic.Name = "Kigali"
+	params.Clinic.Services = []string{"vaccination"}
+
+	body := PostBody{Flow: "d6efc9ff-cf7d-4a5c-b4b3-46eda997d461", URNs: []string{"tel:+250788123123"}, RestartParticipants: &restart, ExcludeActive: true}
+	assert.NoError(t, body.SetParams(params))
+	assert.Error(t, body.SetParams(make(chan int)))
+
+	flowStart, err := service.Post(body)
+	assert.NoError(t, err)
+
+	decoded := surveyParams{}
+	assert.NoError(t, flowStart.DecodeParams(&decoded))
+	assert.Equal(t, params, decoded)
+}
+
+func TestFlowStartsDecodeParams(t *testing.T) {
+	response := &Response{}
+	assert.NoError(t, json.Unmarshal([]byte(testDataGet), response))
+	params := surveyParams{}
+	assert.NoError(t, response.Results[0].DecodeParams(&params))
+	assert.Equal(t, "Ryan", params.FirstName)
+
+	flowStart := &FlowStart{}
+	assert.NoError(t, json.Unmarshal([]byte(testDataPost), flowStart))
+	params = surveyParams{FirstName: "unchanged"}
+	assert.NoError(t, flowStart.DecodeParams(&params))
+	assert.Equal(t, "unchanged", params.FirstName)
+}
+
+func TestFlowStartsCursor(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "cD0yMDE2", r.URL.Query().Get("cursor"))
+			w.Write([]byte(`{"next": "https://rapidpro.io/api/v2/flow_starts.json?cursor=cD0yMDE1", "previous": null, "results": []}`))
+		}))
+	defer mockServer.Close()
+
+	defaultClient := &client.Client{
+		Credentials: &client.Credentials{Token: "token123"},
+	}
+	service := NewService(client.NewRequestHandler(defaultClient), mockServer.URL)
+	resp, err := service.Get(&QueryParams{Cursor: "cD0yMDE2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "cD0yMDE1", resp.NextCursor())
+}
+
 var (
 	testDataGet = `
 	{
@@ -0,0 +1,86 @@
+package flowstarts
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrStartNotFound is returned by WaitForStart when no flow start has the given uuid
+var ErrStartNotFound = errors.New("flow start not found")
+
+// WaitOptions configures how WaitForStart polls a flow start
+type WaitOptions struct {
+	// Interval is the wait after the first poll, zero means one second
+	Interval time.Duration
+	// MaxInterval caps the wait between polls as it backs off, zero means 30 seconds
+	MaxInterval time.Duration
+	// Multiplier is applied to the wait after each poll, anything below 1 means 2
+	Multiplier float64
+	// Timeout bounds the whole wait in addition to the context deadline, zero means no timeout
+	Timeout time.Duration
+	// OnProgress is called with the flow start after every poll
+	OnProgress func(flowStart *FlowStart)
+}
+
+// IsFinished returns whether the flow start has reached a final status, i.e. complete, failed or interrupted
+func (f *FlowStart) IsFinished() bool {
+	return f.Status == StatusComplete || f.Status == StatusFailed || f.Status == StatusInterrupted
+}
+
+// WaitForStart polls the flow start with the given uuid, backing off between polls, until it is finished and
+// returns it. If the wait times out or ctx is done, the last polled flow start is returned along with the error.
+func (s *ApiService) WaitForStart(ctx context.Context, uuid string, opts *WaitOptions) (*FlowStart, error) {
+	if opts == nil {
+		opts = &WaitOptions{}
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = 30 * time.Second
+	}
+	multiplier := opts.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	var last *FlowStart
+	for {
+		if err := ctx.Err(); err != nil {
+			return last, errors.Wrapf(err, "waiting for flow start %s", uuid)
+		}
+
+		response, err := s.Get(&QueryParams{UUID: uuid})
+		if err != nil {
+			return last, err
+		}
+		if len(response.Results) == 0 {
+			return nil, ErrStartNotFound
+		}
+		last = &response.Results[0]
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(last)
+		}
+		if last.IsFinished() {
+			return last, nil
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return last, errors.Wrapf(err, "waiting for flow start %s", uuid)
+		}
+		interval = time.Duration(float64(interval) * multiplier)
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package flowstarts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type statusServer struct {
+	mutex    sync.Mutex
+	statuses []string
+	polls    int
+}
+
+func (s *statusServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if r.URL.Query().Get("uuid") != "09d23a05-47fe-11e4-bfe9-b8f6b119e9ab" {
+		w.Write([]byte(`{"next": null, "previous": null, "results": []}`))
+		return
+	}
+	status := s.statuses[len(s.statuses)-1]
+	if s.polls < len(s.statuses) {
+		status = s.statuses[s.polls]
+	}
+	s.polls++
+	fmt.Fprintf(w, `{"next": null, "previous": null, "results": [{"uuid": "09d23a05-47fe-11e4-bfe9-b8f6b119e9ab", "status": "%s"}]}`, status)
+}
+
+func TestWaitForStart(t *testing.T) {
+	server := &statusServer{statuses: []string{StatusPending, StatusStarting, StatusStarting, StatusComplete}}
+	service, closeServer := newBulkService(server)
+	defer closeServer()
+
+	var progress []string
+	opts := &WaitOptions{
+		Interval:    time.Millisecond,
+		MaxInterval: 2 * time.Millisecond,
+		OnProgress:  func(flowStart *FlowStart) { progress = append(progress, flowStart.Status) },
+	}
+	flowStart, err := service.WaitForStart(context.Background(), "09d23a05-47fe-11e4-bfe9-b8f6b119e9ab", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusComplete, flowStart.Status)
+	assert.True(t, flowStart.IsFinished())
+	assert.Equal(t, server.statuses, progress)
+}
+
+func TestWaitForStartFailed(t *testing.T) {
+	server := &statusServer{statuses: []string{StatusFailed}}
+	service, closeServer := newBulkService(server)
+	defer closeServer()
+
+	flowStart, err := service.WaitForStart(context.Background(), "09d23a05-47fe-11e4-bfe9-b8f6b119e9ab", &WaitOptions{Interval: time.Millisecond})
+	assert.NoError(t, err)
+	assert.Equal(t, StatusFailed, flowStart.Status)
+}
+
+func TestWaitForStartInterrupted(t *testing.T) {
+	server := &statusServer{statuses: []string{StatusStarting, StatusInterrupted}}
+	service, closeServer := newBulkService(server)
+	defer closeServer()
+
+	flowStart, err := service.WaitForStart(context.Background(), "09d23a05-47fe-11e4-bfe9-b8f6b119e9ab", &WaitOptions{Interval: time.Millisecond})
+	assert.NoError(t, err)
+	assert.Equal(t, StatusInterrupted, flowStart.Status)
+	assert.True(t, flowStart.IsFinished())
+	assert.Equal(t, 2, server.polls)
+}
+
+func TestWaitForStartAlreadyFinished(t *testing.T) {
+	server := &statusServer{statuses: []string{StatusComplete}}
+	service, closeServer := newBulkService(server)
+	defer closeServer()
+
+	// the default interval is only waited between polls
+	started := time.Now()
+	flowStart, err := service.WaitForStart(context.Background(), "09d23a05-47fe-11e4-bfe9-b8f6b119e9ab", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusComplete, flowStart.Status)
+	assert.Equal(t, 1, server.polls)
+	assert.Less(t, int64(time.Since(started)), int64(time.Second))
+}
+
+func TestWaitForStartTimeout(t *testing.T) {
+	server := &statusServer{statuses: []string{StatusStarting}}
+	service, closeServer := newBulkService(server)
+	defer closeServer()
+
+	opts := &WaitOptions{Interval: time.Millisecond, Multiplier: 1, Timeout: 20 * time.Millisecond}
+	flowStart, err := service.WaitForStart(context.Background(), "09d23a05-47fe-11e4-bfe9-b8f6b119e9ab", opts)
+	assert.Equal(t, context.DeadlineExceeded, errors.Cause(err))
+	assert.Equal(t, StatusStarting, flowStart.Status)
+}
+
+func TestWaitForStartNotFound(t *testing.T) {
+	service, closeServer := newBulkService(&statusServer{})
+	defer closeServer()
+
+	_, err := service.WaitForStart(context.Background(), "f5901b62-ba76-4003-9c62-72fdacc1b7b7", &WaitOptions{Interval: time.Millisecond})
+	assert.Equal(t, ErrStartNotFound, err)
+}
+
+func TestWaitForStartCancelled(t *testing.T) {
+	service, closeServer := newBulkService(&statusServer{})
+	defer closeServer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	flowStart, err := service.WaitForStart(ctx, "09d23a05-47fe-11e4-bfe9-b8f6b119e9ab", nil)
+	assert.Nil(t, flowStart)
+	assert.Equal(t, context.Canceled, errors.Cause(err))
+}
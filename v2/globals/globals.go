@@ -0,0 +1,108 @@
+package globals
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+const PATH = "/v2/globals.json"
+
+type ApiService struct {
+	serviceURL     string
+	requestHandler *rapidpro.RequestHandler
+}
+
+func NewService(requestHandler *rapidpro.RequestHandler, apiURL string) *ApiService {
+	return &ApiService{
+		requestHandler: requestHandler,
+		serviceURL:     apiURL + PATH,
+	}
+}
+
+// Get makes a GET request to globals endpoint with *QueryParams and returns a Response.
+func (s *ApiService) Get(params *QueryParams) (*Response, error) {
+	data := url.Values{}
+	headers := make(map[string]interface{})
+
+	if params != nil {
+		if params.Key != "" {
+			data.Set("key", params.Key)
+		}
+		if params.Before != nil {
+			data.Set("before", params.Before.Format(time.RFC3339))
+		}
+		if params.After != nil {
+			data.Set("after", params.After.Format(time.RFC3339))
+		}
+	}
+
+	resp, err := s.requestHandler.Get(s.serviceURL, data, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Response{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Post makes a POST request to globals endpoint to create a new global and returns it.
+func (s *ApiService) Post(body PostBody) (*Global, error) {
+	return s.post(url.Values{}, body)
+}
+
+// Update makes a POST request to globals endpoint to update the value of the global with the given key and returns it.
+func (s *ApiService) Update(key string, body PostBody) (*Global, error) {
+	queryParams := url.Values{}
+	queryParams.Set("key", key)
+	return s.post(queryParams, body)
+}
+
+func (s *ApiService) post(queryParams url.Values, body PostBody) (*Global, error) {
+	resp, err := s.requestHandler.Post(s.serviceURL, queryParams, body, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Global{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Global represents a global object
+type Global struct {
+	Key        string     `json:"key,omitempty"`
+	Name       string     `json:"name,omitempty"`
+	Value      string     `json:"value"`
+	ModifiedOn *time.Time `json:"modified_on,omitempty"`
+}
+
+// Response represents the response of a request in globals endpoint
+type Response struct {
+	Next     interface{} `json:"next"`
+	Previous interface{} `json:"previous"`
+	Results  []Global    `json:"results"`
+}
+
+// QueryParams is a struct that represents the query parameters that can be passed in a request to globals endpoint
+type QueryParams struct {
+	Key    string     `json:"key,omitempty"`
+	Before *time.Time `json:"before,omitempty"`
+	After  *time.Time `json:"after,omitempty"`
+}
+
+// PostBody represents the body of a request to create or update a global, only Value can be updated
+type PostBody struct {
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value"`
+}
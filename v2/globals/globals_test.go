@@ -0,0 +1,164 @@
+package globals
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type GlobalsTestCase struct {
+	Label        string
+	Method       string
+	Key          string
+	QueryParams  *QueryParams
+	PostBody     *PostBody
+	Status       int
+	ResponseBody string
+	ResultsCount int
+	Error        error
+}
+
+var testCases = []GlobalsTestCase{
+	{
+		Label:        "Test Get globals",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       200,
+		ResponseBody: testDataGet[0],
+		ResultsCount: 1,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Get globals with response error",
+		Method:       "GET",
+		Status:       200,
+		ResponseBody: "{",
+		Error:        errors.New("unexpected EOF"),
+	},
+	{
+		Label:  "Test Get globals with params",
+		Method: "GET",
+		QueryParams: &QueryParams{
+			Key:    "org_name",
+			After:  timeToPointer(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Before: timeToPointer(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		Status:       200,
+		ResponseBody: testDataGet[1],
+		ResultsCount: 0,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Post globals",
+		Method:       "POST",
+		PostBody:     &PostBody{Name: "Org Name", Value: "Acme Ltd"},
+		Status:       201,
+		ResponseBody: testDataPost,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Update globals",
+		Method:       "POST",
+		Key:          "org_name",
+		PostBody:     &PostBody{Value: "Acme Ltd"},
+		Status:       200,
+		ResponseBody: testDataPost,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Post globals with error",
+		Method:       "POST",
+		PostBody:     &PostBody{Value: "Acme Ltd"},
+		Status:       400,
+		ResponseBody: `{"name": ["This field is required."]}`,
+		Error: &client.RapidproRestError{
+			Status:  400,
+			Details: map[string]interface{}{"name": []interface{}{"This field is required."}},
+		},
+	},
+}
+
+func timeToPointer(t time.Time) *time.Time { return &t }
+
+func TestGlobals(t *testing.T) {
+	for _, tc := range testCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if tc.Method == "POST" {
+					assert.Equal(t, tc.Key, r.URL.Query().Get("key"))
+					body := PostBody{}
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, *tc.PostBody, body)
+				}
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			if tc.Method == "GET" {
+				resp, err := service.Get(tc.QueryParams)
+				if err != nil {
+					assert.Equal(t, tc.Error.Error(), err.Error())
+				}
+				if tc.ResultsCount > 0 {
+					assert.Equal(t, tc.ResultsCount, len(resp.Results))
+				}
+				return
+			}
+
+			var global *Global
+			var err error
+			if tc.Key != "" {
+				global, err = service.Update(tc.Key, *tc.PostBody)
+			} else {
+				global, err = service.Post(*tc.PostBody)
+			}
+			assert.Equal(t, tc.Error, err)
+			if err == nil {
+				assert.Equal(t, "org_name", global.Key)
+				assert.Equal(t, "Acme Ltd", global.Value)
+				assert.NotNil(t, global.ModifiedOn)
+			}
+		})
+	}
+}
+
+var testDataGet = []string{
+	`{
+		"next": null,
+		"previous": null,
+		"results": [
+			{
+				"key": "org_name",
+				"name": "Org Name",
+				"value": "Acme Ltd",
+				"modified_on": "2013-02-27T09:06:15.456Z"
+			}
+		]
+	}`,
+	`{
+		"next": null,
+		"previous": null,
+		"results": []
+	}`,
+}
+
+var testDataPost = `{
+	"key": "org_name",
+	"name": "Org Name",
+	"value": "Acme Ltd",
+	"modified_on": "2013-02-27T09:06:15.456Z"
+}`
@@ -0,0 +1,76 @@
+package media
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+
+	"github.com/pkg/errors"
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+const PATH = "/v2/media.json"
+
+type ApiService struct {
+	serviceURL     string
+	requestHandler *rapidpro.RequestHandler
+}
+
+func NewService(requestHandler *rapidpro.RequestHandler, apiURL string) *ApiService {
+	return &ApiService{
+		requestHandler: requestHandler,
+		serviceURL:     apiURL + PATH,
+	}
+}
+
+// Post makes a multipart POST request to media endpoint to upload a file and returns the hosted Media.
+func (s *ApiService) Post(body PostBody) (*Media, error) {
+	if body.File == nil {
+		return nil, errors.New("media file is required")
+	}
+
+	multipartBody := &rapidpro.MultipartBody{
+		Files: []rapidpro.MultipartFile{
+			{
+				FieldName:   "file",
+				FileName:    body.Filename,
+				ContentType: body.ContentType,
+				Reader:      body.File,
+			},
+		},
+	}
+
+	resp, err := s.requestHandler.Post(s.serviceURL, url.Values{}, multipartBody, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Media{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Media represents an uploaded media object
+type Media struct {
+	UUID        string `json:"uuid,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Filename    string `json:"filename,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+}
+
+// Attachment returns the media in the content_type:url form used for attachments in flow definitions.
+// Messages are sent with the UUID of the media instead.
+func (m *Media) Attachment() string {
+	return m.ContentType + ":" + m.URL
+}
+
+// PostBody represents a file to upload to media endpoint
+type PostBody struct {
+	File        io.Reader
+	ContentType string
+	Filename    string
+}
@@ -0,0 +1,87 @@
+package media
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type MediaTestCase struct {
+	Label        string
+	PostBody     PostBody
+	Status       int
+	ResponseBody string
+	Error        error
+}
+
+var testCases = []MediaTestCase{
+	{
+		Label:        "Test Post media",
+		PostBody:     PostBody{File: strings.NewReader("jpegdata"), ContentType: "image/jpeg", Filename: "photo.jpg"},
+		Status:       201,
+		ResponseBody: testData,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Post media with error",
+		PostBody:     PostBody{File: strings.NewReader("jpegdata"), ContentType: "image/jpeg", Filename: "photo.jpg"},
+		Status:       400,
+		ResponseBody: `{"file": ["Unsupported file type."]}`,
+		Error: &client.RapidproRestError{
+			Status:  400,
+			Details: map[string]interface{}{"file": []interface{}{"Unsupported file type."}},
+		},
+	},
+}
+
+func TestMedia(t *testing.T) {
+	for _, tc := range testCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				file, header, err := r.FormFile("file")
+				assert.NoError(t, err)
+				assert.Equal(t, "photo.jpg", header.Filename)
+				assert.Equal(t, "image/jpeg", header.Header.Get("Content-Type"))
+				content, _ := ioutil.ReadAll(file)
+				assert.Equal(t, "jpegdata", string(content))
+
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			media, err := service.Post(tc.PostBody)
+			assert.Equal(t, tc.Error, err)
+			if err == nil {
+				assert.Equal(t, "https://rapidpro.io/media/photo.jpg", media.URL)
+				assert.Equal(t, "image/jpeg:https://rapidpro.io/media/photo.jpg", media.Attachment())
+			}
+		})
+	}
+}
+
+func TestMediaWithoutFile(t *testing.T) {
+	service := NewService(nil, "")
+	_, err := service.Post(PostBody{Filename: "photo.jpg"})
+	assert.EqualError(t, err, "media file is required")
+}
+
+var testData = `{
+	"uuid": "3b8b7a8e-8e2b-4b8e-9b1e-4c0a6b2d9f1e",
+	"content_type": "image/jpeg",
+	"url": "https://rapidpro.io/media/photo.jpg",
+	"filename": "photo.jpg",
+	"size": 8
+}`
@@ -50,6 +50,9 @@ func (s *ApiService) Get(params *QueryParams) (*Response, error) {
 		if params.After != nil {
 			data.Set("after", params.After.Format(time.RFC3339))
 		}
+		if params.Cursor != "" {
+			data.Set("cursor", params.Cursor)
+		}
 	}
 
 	resp, err := s.requestHandler.Get(s.serviceURL, data, headers)
@@ -66,6 +69,21 @@ func (s *ApiService) Get(params *QueryParams) (*Response, error) {
 	return response, nil
 }
 
+// Post makes a POST request to messages endpoint to send a message to a single contact and returns the created Message.
+func (s *ApiService) Post(body PostBody) (*Message, error) {
+	resp, err := s.requestHandler.Post(s.serviceURL, url.Values{}, body, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Message{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 // Message represents a message objects
 type Message struct {
 	ID        int `json:"id,omitempty"`
@@ -104,6 +122,11 @@ type Response struct {
 	Results  []Message   `json:"results"`
 }
 
+// NextCursor returns the cursor to pass in QueryParams to get the next page, or an empty string on the last page
+func (r *Response) NextCursor() string {
+	return rapidpro.Cursor(r.Next)
+}
+
 // QueryParams is a struct that represents the query parameters that can be passed in a request to messages endpoint
 type QueryParams struct {
 	ID        int        `json:"id,omitempty"`
@@ -113,4 +136,14 @@ type QueryParams struct {
 	Label     string     `json:"label,omitempty"`
 	Before    *time.Time `json:"before,omitempty"`
 	After     *time.Time `json:"after,omitempty"`
+	Cursor    string     `json:"cursor,omitempty"`
+}
+
+// PostBody represents the body of a request to send a message.
+// Attachments are the UUIDs of uploaded media, see the media package.
+type PostBody struct {
+	Contact      string   `json:"contact"`
+	Text         string   `json:"text,omitempty"`
+	Attachments  []string `json:"attachments,omitempty"`
+	QuickReplies []string `json:"quick_replies,omitempty"`
 }
@@ -1,6 +1,7 @@
 package messages
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/rasoro/rapidpro-api-go/v2/media"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -102,6 +104,128 @@ func TestMessages(t *testing.T) {
 	}
 }
 
+func TestMessagesPost(t *testing.T) {
+	postCases := []struct {
+		Label        string
+		PostBody     PostBody
+		Status       int
+		ResponseBody string
+		Error        error
+	}{
+		{
+			Label: "Test Post messages",
+			PostBody: PostBody{
+				Contact:      "d33e9ad5-5c35-414c-abd4-e7451c69ff1d",
+				Text:         "How are you?",
+				Attachments:  []string{"3b8b7a8e-8e2b-4b8e-9b1e-4c0a6b2d9f1e"},
+				QuickReplies: []string{"Good", "Bad"},
+			},
+			Status:       201,
+			ResponseBody: testDataPost,
+			Error:        nil,
+		},
+		{
+			Label:        "Test Post messages with error",
+			PostBody:     PostBody{Text: "How are you?"},
+			Status:       400,
+			ResponseBody: `{"contact": ["This field is required."]}`,
+			Error: &client.RapidproRestError{
+				Status:  400,
+				Details: map[string]interface{}{"contact": []interface{}{"This field is required."}},
+			},
+		},
+	}
+
+	for _, tc := range postCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				body := PostBody{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, tc.PostBody, body)
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			message, err := service.Post(tc.PostBody)
+			assert.Equal(t, tc.Error, err)
+			if err == nil {
+				assert.Equal(t, 4105426, message.ID)
+				assert.Equal(t, "queued", message.Status)
+				assert.Equal(t, "https://rapidpro.io/media/photo.jpg", message.Attachments[0].URL)
+			}
+		})
+	}
+}
+
+func TestMessagesPostMedia(t *testing.T) {
+	uploaded := &media.Media{
+		UUID:        "3b8b7a8e-8e2b-4b8e-9b1e-4c0a6b2d9f1e",
+		ContentType: "image/jpeg",
+		URL:         "https://rapidpro.io/media/photo.jpg",
+	}
+	mockServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body := map[string]interface{}{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, []interface{}{"3b8b7a8e-8e2b-4b8e-9b1e-4c0a6b2d9f1e"}, body["attachments"])
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(201)
+			w.Write([]byte(testDataPost))
+		}))
+	defer mockServer.Close()
+
+	requestHandler := client.NewRequestHandler(&client.Client{Credentials: &client.Credentials{Token: "token123"}})
+	service := NewService(requestHandler, mockServer.URL)
+	_, err := service.Post(PostBody{
+		Contact:     "d33e9ad5-5c35-414c-abd4-e7451c69ff1d",
+		Attachments: []string{uploaded.UUID},
+	})
+	assert.NoError(t, err)
+}
+
+func TestMessagesCursor(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "cD0yMDE2", r.URL.Query().Get("cursor"))
+			w.Write([]byte(`{"next": "https://rapidpro.io/api/v2/messages.json?cursor=cD0yMDE1", "previous": null, "results": []}`))
+		}))
+	defer mockServer.Close()
+
+	defaultClient := &client.Client{
+		Credentials: &client.Credentials{Token: "token123"},
+	}
+	service := NewService(client.NewRequestHandler(defaultClient), mockServer.URL)
+	resp, err := service.Get(&QueryParams{Cursor: "cD0yMDE2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "cD0yMDE1", resp.NextCursor())
+}
+
+var testDataPost = `{
+	"id": 4105426,
+	"broadcast": null,
+	"contact": {"uuid": "d33e9ad5-5c35-414c-abd4-e7451c69ff1d", "name": "Bob McFlow"},
+	"urn": "tel:+593979000111",
+	"channel": {"uuid": "9a8b001e-a913-486c-80f4-1356e23f582e", "name": "Vonage"},
+	"direction": "out",
+	"type": "text",
+	"status": "queued",
+	"visibility": "visible",
+	"text": "How are you?",
+	"attachments": [{"content_type": "image/jpeg", "url": "https://rapidpro.io/media/photo.jpg"}],
+	"labels": [],
+	"created_on": "2016-01-06T15:33:00.813162Z",
+	"sent_on": null,
+	"modified_on": "2016-01-06T15:33:00.813162Z"
+}`
+
 var testData = []string{
 	`{
 		"next": null,
@@ -0,0 +1,102 @@
+package optins
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+const PATH = "/v2/optins.json"
+
+type ApiService struct {
+	serviceURL     string
+	requestHandler *rapidpro.RequestHandler
+}
+
+func NewService(requestHandler *rapidpro.RequestHandler, apiURL string) *ApiService {
+	return &ApiService{
+		requestHandler: requestHandler,
+		serviceURL:     apiURL + PATH,
+	}
+}
+
+// Get makes a GET request to optins endpoint with *QueryParams and returns a Response.
+func (s *ApiService) Get(params *QueryParams) (*Response, error) {
+	data := url.Values{}
+	headers := make(map[string]interface{})
+
+	if params != nil {
+		if params.Before != nil {
+			data.Set("before", params.Before.Format(time.RFC3339))
+		}
+		if params.After != nil {
+			data.Set("after", params.After.Format(time.RFC3339))
+		}
+	}
+
+	resp, err := s.requestHandler.Get(s.serviceURL, data, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Response{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Post makes a POST request to optins endpoint to create a new opt-in and returns it.
+func (s *ApiService) Post(body PostBody) (*OptIn, error) {
+	resp, err := s.requestHandler.Post(s.serviceURL, url.Values{}, body, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &OptIn{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// OptIn represents an opt-in object
+type OptIn struct {
+	UUID      string     `json:"uuid,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	CreatedOn *time.Time `json:"created_on,omitempty"`
+}
+
+// Reference returns the opt-in as a reference to be used in broadcasts and messages
+func (o *OptIn) Reference() *Reference {
+	return &Reference{UUID: o.UUID, Name: o.Name}
+}
+
+// Reference represents a reference to an opt-in in a request body
+type Reference struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name,omitempty"`
+}
+
+// Response represents the response of a request in optins endpoint
+type Response struct {
+	Next     interface{} `json:"next"`
+	Previous interface{} `json:"previous"`
+	Results  []OptIn     `json:"results"`
+}
+
+// QueryParams is a struct that represents the query parameters that can be passed in a request to optins endpoint
+type QueryParams struct {
+	Before *time.Time `json:"before,omitempty"`
+	After  *time.Time `json:"after,omitempty"`
+}
+
+// PostBody represents the body of a request to create an opt-in
+type PostBody struct {
+	Name string `json:"name"`
+}
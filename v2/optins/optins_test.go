@@ -0,0 +1,142 @@
+package optins
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type OptInsTestCase struct {
+	Label        string
+	Method       string
+	QueryParams  *QueryParams
+	PostBody     *PostBody
+	Status       int
+	ResponseBody string
+	ResultsCount int
+	Error        error
+}
+
+var testCases = []OptInsTestCase{
+	{
+		Label:        "Test Get optins",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       200,
+		ResponseBody: testDataGet[0],
+		ResultsCount: 1,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Get optins with response error",
+		Method:       "GET",
+		Status:       200,
+		ResponseBody: "{",
+		Error:        errors.New("unexpected EOF"),
+	},
+	{
+		Label:  "Test Get optins with params",
+		Method: "GET",
+		QueryParams: &QueryParams{
+			After:  timeToPointer(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Before: timeToPointer(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		Status:       200,
+		ResponseBody: testDataGet[1],
+		ResultsCount: 0,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Post optins",
+		Method:       "POST",
+		PostBody:     &PostBody{Name: "Jokes"},
+		Status:       201,
+		ResponseBody: testDataPost,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Post optins with error",
+		Method:       "POST",
+		PostBody:     &PostBody{Name: "Jokes"},
+		Status:       400,
+		ResponseBody: `{"name": ["This field must be unique."]}`,
+		Error: &client.RapidproRestError{
+			Status:  400,
+			Details: map[string]interface{}{"name": []interface{}{"This field must be unique."}},
+		},
+	},
+}
+
+func timeToPointer(t time.Time) *time.Time { return &t }
+
+func TestOptIns(t *testing.T) {
+	for _, tc := range testCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if tc.Method == "POST" {
+					body := PostBody{}
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, *tc.PostBody, body)
+				}
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			if tc.Method == "GET" {
+				resp, err := service.Get(tc.QueryParams)
+				if err != nil {
+					assert.Equal(t, tc.Error.Error(), err.Error())
+				}
+				if tc.ResultsCount > 0 {
+					assert.Equal(t, tc.ResultsCount, len(resp.Results))
+				}
+				return
+			}
+
+			optIn, err := service.Post(*tc.PostBody)
+			assert.Equal(t, tc.Error, err)
+			if err == nil {
+				assert.Equal(t, &Reference{UUID: "9a8b001e-a913-486c-80f4-1356e23f582e", Name: "Jokes"}, optIn.Reference())
+			}
+		})
+	}
+}
+
+var testDataGet = []string{
+	`{
+		"next": null,
+		"previous": null,
+		"results": [
+			{
+				"uuid": "9a8b001e-a913-486c-80f4-1356e23f582e",
+				"name": "Jokes",
+				"created_on": "2013-02-27T09:06:15.456Z"
+			}
+		]
+	}`,
+	`{
+		"next": null,
+		"previous": null,
+		"results": []
+	}`,
+}
+
+var testDataPost = `{
+	"uuid": "9a8b001e-a913-486c-80f4-1356e23f582e",
+	"name": "Jokes",
+	"created_on": "2013-02-27T09:06:15.456Z"
+}`
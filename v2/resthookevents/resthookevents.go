@@ -0,0 +1,75 @@
+package resthookevents
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+const PATH = "/v2/resthook_events.json"
+
+type ApiService struct {
+	serviceURL     string
+	requestHandler *rapidpro.RequestHandler
+}
+
+func NewService(requestHandler *rapidpro.RequestHandler, apiURL string) *ApiService {
+	return &ApiService{
+		requestHandler: requestHandler,
+		serviceURL:     apiURL + PATH,
+	}
+}
+
+// Get makes a GET request to resthook events endpoint with *QueryParams and returns a Response.
+func (s *ApiService) Get(params *QueryParams) (*Response, error) {
+	data := url.Values{}
+	headers := make(map[string]interface{})
+
+	if params != nil {
+		if params.Resthook != "" {
+			data.Set("resthook", params.Resthook)
+		}
+		if params.Before != nil {
+			data.Set("before", params.Before.Format(time.RFC3339))
+		}
+		if params.After != nil {
+			data.Set("after", params.After.Format(time.RFC3339))
+		}
+	}
+
+	resp, err := s.requestHandler.Get(s.serviceURL, data, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Response{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Event represents a resthook event object, Data holds the payload that was sent to subscribers
+type Event struct {
+	Resthook  string          `json:"resthook,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	CreatedOn *time.Time      `json:"created_on,omitempty"`
+}
+
+// Response represents the response of a request in resthook events endpoint
+type Response struct {
+	Next     interface{} `json:"next"`
+	Previous interface{} `json:"previous"`
+	Results  []Event     `json:"results"`
+}
+
+// QueryParams is a struct that represents the query parameters that can be passed in a request to resthook events endpoint
+type QueryParams struct {
+	Resthook string     `json:"resthook,omitempty"`
+	Before   *time.Time `json:"before,omitempty"`
+	After    *time.Time `json:"after,omitempty"`
+}
@@ -0,0 +1,115 @@
+package resthookevents
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type ResthookEventsTestCase struct {
+	Label        string
+	Method       string
+	QueryParams  *QueryParams
+	Status       int
+	ResponseBody string
+	ResultsCount int
+	Error        error
+}
+
+var testCases = []ResthookEventsTestCase{
+	{
+		Label:        "Test Get resthook events",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       200,
+		ResponseBody: testData[0],
+		ResultsCount: 1,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Get resthook events with response error",
+		Method:       "GET",
+		Status:       200,
+		ResponseBody: "{",
+		Error:        errors.New("unexpected EOF"),
+	},
+	{
+		Label:  "Test Get resthook events with params",
+		Method: "GET",
+		QueryParams: &QueryParams{
+			Resthook: "new-report",
+			After:    timeToPointer(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Before:   timeToPointer(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		Status:       200,
+		ResponseBody: testData[1],
+		ResultsCount: 0,
+		Error:        nil,
+	},
+}
+
+func timeToPointer(t time.Time) *time.Time { return &t }
+
+func TestResthookEvents(t *testing.T) {
+	for _, tc := range testCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			resp, err := service.Get(tc.QueryParams)
+			if err != nil {
+				assert.Equal(t, tc.Error.Error(), err.Error())
+			}
+			if tc.ResultsCount > 0 {
+				assert.Equal(t, tc.ResultsCount, len(resp.Results))
+
+				payload := map[string]interface{}{}
+				assert.NoError(t, json.Unmarshal(resp.Results[0].Data, &payload))
+				assert.Equal(t, "Bob McFlow", payload["contact"].(map[string]interface{})["name"])
+			}
+		})
+	}
+}
+
+var testData = []string{
+	`{
+		"next": null,
+		"previous": null,
+		"results": [
+			{
+				"resthook": "new-report",
+				"data": {
+					"flow": {"name": "Water Survey", "uuid": "13fed2d2-160e-48e5-b52e-6eea3f74f27d"},
+					"contact": {"uuid": "dc2b3709-3261-465f-b39a-fc7312b2ab95", "name": "Bob McFlow", "urn": "tel:+12067799294"},
+					"channel": {"uuid": "9e6beda-0ce2-46cd-8810-91157f261cbd", "name": "Nexmo"},
+					"run": {"uuid": "gcc7a253-6fe1-4bca-bd1a-a93a4f8a6e10", "created_on": "2016-09-05T18:54:30.461Z"},
+					"input": {"urn": "tel:+12067799294", "text": "stream"},
+					"path": [],
+					"results": {}
+				},
+				"created_on": "2017-11-11T13:05:57.457742Z"
+			}
+		]
+	}`,
+	`{
+		"next": null,
+		"previous": null,
+		"results": []
+	}`,
+}
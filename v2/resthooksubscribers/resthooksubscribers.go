@@ -0,0 +1,114 @@
+package resthooksubscribers
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+const PATH = "/v2/resthook_subscribers.json"
+
+type ApiService struct {
+	serviceURL     string
+	requestHandler *rapidpro.RequestHandler
+}
+
+func NewService(requestHandler *rapidpro.RequestHandler, apiURL string) *ApiService {
+	return &ApiService{
+		requestHandler: requestHandler,
+		serviceURL:     apiURL + PATH,
+	}
+}
+
+// Get makes a GET request to resthook subscribers endpoint with *QueryParams and returns a Response.
+func (s *ApiService) Get(params *QueryParams) (*Response, error) {
+	data := url.Values{}
+	headers := make(map[string]interface{})
+
+	if params != nil {
+		if params.ID != 0 {
+			data.Set("id", strconv.Itoa(params.ID))
+		}
+		if params.Resthook != "" {
+			data.Set("resthook", params.Resthook)
+		}
+		if params.Before != nil {
+			data.Set("before", params.Before.Format(time.RFC3339))
+		}
+		if params.After != nil {
+			data.Set("after", params.After.Format(time.RFC3339))
+		}
+	}
+
+	resp, err := s.requestHandler.Get(s.serviceURL, data, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Response{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Post makes a POST request to resthook subscribers endpoint to subscribe a target URL to a resthook and returns the Subscriber.
+func (s *ApiService) Post(body PostBody) (*Subscriber, error) {
+	resp, err := s.requestHandler.Post(s.serviceURL, url.Values{}, body, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Subscriber{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Delete makes a DELETE request to resthook subscribers endpoint to remove the subscriber with the given id.
+func (s *ApiService) Delete(id int) error {
+	queryParams := url.Values{}
+	queryParams.Set("id", strconv.Itoa(id))
+
+	resp, err := s.requestHandler.Delete(s.serviceURL, queryParams, nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Subscriber represents a resthook subscriber object
+type Subscriber struct {
+	ID        int        `json:"id,omitempty"`
+	Resthook  string     `json:"resthook,omitempty"`
+	TargetURL string     `json:"target_url,omitempty"`
+	CreatedOn *time.Time `json:"created_on,omitempty"`
+}
+
+// Response represents the response of a request in resthook subscribers endpoint
+type Response struct {
+	Next     interface{}  `json:"next"`
+	Previous interface{}  `json:"previous"`
+	Results  []Subscriber `json:"results"`
+}
+
+// QueryParams is a struct that represents the query parameters that can be passed in a request to resthook subscribers endpoint
+type QueryParams struct {
+	ID       int        `json:"id,omitempty"`
+	Resthook string     `json:"resthook,omitempty"`
+	Before   *time.Time `json:"before,omitempty"`
+	After    *time.Time `json:"after,omitempty"`
+}
+
+// PostBody represents the body of a request to create a resthook subscriber
+type PostBody struct {
+	Resthook  string `json:"resthook"`
+	TargetURL string `json:"target_url"`
+}
@@ -0,0 +1,174 @@
+package resthooksubscribers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type ResthookSubscribersTestCase struct {
+	Label        string
+	Method       string
+	QueryParams  *QueryParams
+	PostBody     *PostBody
+	DeleteID     int
+	Status       int
+	ResponseBody string
+	ResultsCount int
+	Error        error
+}
+
+var testCases = []ResthookSubscribersTestCase{
+	{
+		Label:        "Test Get resthook subscribers",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       200,
+		ResponseBody: testDataGet[0],
+		ResultsCount: 1,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Get resthook subscribers with response error",
+		Method:       "GET",
+		Status:       200,
+		ResponseBody: "{",
+		Error:        errors.New("unexpected EOF"),
+	},
+	{
+		Label:  "Test Get resthook subscribers with params",
+		Method: "GET",
+		QueryParams: &QueryParams{
+			ID:       10404,
+			Resthook: "new-report",
+			After:    timeToPointer(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Before:   timeToPointer(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		Status:       200,
+		ResponseBody: testDataGet[1],
+		ResultsCount: 0,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Post resthook subscribers",
+		Method:       "POST",
+		PostBody:     &PostBody{Resthook: "new-report", TargetURL: "https://zapier.com/receive/505019595"},
+		Status:       201,
+		ResponseBody: testDataPost,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Post resthook subscribers with error",
+		Method:       "POST",
+		PostBody:     &PostBody{Resthook: "missing", TargetURL: "https://zapier.com/receive/505019595"},
+		Status:       400,
+		ResponseBody: `{"resthook": ["No resthook with slug: missing"]}`,
+		Error: &client.RapidproRestError{
+			Status:  400,
+			Details: map[string]interface{}{"resthook": []interface{}{"No resthook with slug: missing"}},
+		},
+	},
+	{
+		Label:    "Test Delete resthook subscribers",
+		Method:   "DELETE",
+		DeleteID: 10404,
+		Status:   204,
+		Error:    nil,
+	},
+	{
+		Label:        "Test Delete resthook subscribers not found",
+		Method:       "DELETE",
+		DeleteID:     1,
+		Status:       404,
+		ResponseBody: `{"detail": "Not found."}`,
+		Error: &client.RapidproRestError{
+			Status:  404,
+			Details: map[string]interface{}{"detail": "Not found."},
+		},
+	},
+}
+
+func timeToPointer(t time.Time) *time.Time { return &t }
+
+func TestResthookSubscribers(t *testing.T) {
+	for _, tc := range testCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tc.Method, r.Method)
+				switch tc.Method {
+				case "POST":
+					body := PostBody{}
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, *tc.PostBody, body)
+				case "DELETE":
+					assert.Equal(t, strconv.Itoa(tc.DeleteID), r.URL.Query().Get("id"))
+				}
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			switch tc.Method {
+			case "GET":
+				resp, err := service.Get(tc.QueryParams)
+				if err != nil {
+					assert.Equal(t, tc.Error.Error(), err.Error())
+				}
+				if tc.ResultsCount > 0 {
+					assert.Equal(t, tc.ResultsCount, len(resp.Results))
+				}
+			case "POST":
+				subscriber, err := service.Post(*tc.PostBody)
+				assert.Equal(t, tc.Error, err)
+				if err == nil {
+					assert.Equal(t, 10404, subscriber.ID)
+					assert.Equal(t, tc.PostBody.TargetURL, subscriber.TargetURL)
+				}
+			case "DELETE":
+				err := service.Delete(tc.DeleteID)
+				assert.Equal(t, tc.Error, err)
+			}
+		})
+	}
+}
+
+var testDataGet = []string{
+	`{
+		"next": null,
+		"previous": null,
+		"results": [
+			{
+				"id": 10404,
+				"resthook": "new-report",
+				"target_url": "https://zapier.com/receive/505019595",
+				"created_on": "2013-08-19T19:11:21.082Z"
+			}
+		]
+	}`,
+	`{
+		"next": null,
+		"previous": null,
+		"results": []
+	}`,
+}
+
+var testDataPost = `{
+	"id": 10404,
+	"resthook": "new-report",
+	"target_url": "https://zapier.com/receive/505019595",
+	"created_on": "2013-08-19T19:11:21.082Z"
+}`
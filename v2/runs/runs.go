@@ -0,0 +1,142 @@
+package runs
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+const PATH = "/v2/runs.json"
+
+// Exit types of a run, a run that is still active has an empty exit type
+const (
+	ExitTypeCompleted   = "completed"
+	ExitTypeInterrupted = "interrupted"
+	ExitTypeExpired     = "expired"
+)
+
+type ApiService struct {
+	serviceURL     string
+	requestHandler *rapidpro.RequestHandler
+}
+
+func NewService(requestHandler *rapidpro.RequestHandler, apiURL string) *ApiService {
+	return &ApiService{
+		requestHandler: requestHandler,
+		serviceURL:     apiURL + PATH,
+	}
+}
+
+// Get makes a GET request to runs endpoint with *QueryParams and returns a Response.
+func (s *ApiService) Get(params *QueryParams) (*Response, error) {
+	data := url.Values{}
+	headers := make(map[string]interface{})
+
+	if params != nil {
+		if params.ID != 0 {
+			data.Set("id", strconv.Itoa(params.ID))
+		}
+		if params.UUID != "" {
+			data.Set("uuid", params.UUID)
+		}
+		if params.Flow != "" {
+			data.Set("flow", params.Flow)
+		}
+		if params.Contact != "" {
+			data.Set("contact", params.Contact)
+		}
+		if params.Responded != nil {
+			data.Set("responded", strconv.FormatBool(*params.Responded))
+		}
+		if params.Before != nil {
+			data.Set("before", params.Before.Format(time.RFC3339))
+		}
+		if params.After != nil {
+			data.Set("after", params.After.Format(time.RFC3339))
+		}
+		if params.Cursor != "" {
+			data.Set("cursor", params.Cursor)
+		}
+	}
+
+	resp, err := s.requestHandler.Get(s.serviceURL, data, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Response{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Run represents a flow run object
+type Run struct {
+	ID   int    `json:"id,omitempty"`
+	UUID string `json:"uuid,omitempty"`
+	Flow struct {
+		UUID string `json:"uuid,omitempty"`
+		Name string `json:"name,omitempty"`
+	} `json:"flow,omitempty"`
+	Contact struct {
+		UUID string `json:"uuid,omitempty"`
+		Name string `json:"name,omitempty"`
+		URN  string `json:"urn,omitempty"`
+	} `json:"contact,omitempty"`
+	Start *struct {
+		UUID string `json:"uuid,omitempty"`
+	} `json:"start,omitempty"`
+	Responded  bool             `json:"responded"`
+	Path       []Step           `json:"path,omitempty"`
+	Values     map[string]Value `json:"values,omitempty"`
+	CreatedOn  *time.Time       `json:"created_on,omitempty"`
+	ModifiedOn *time.Time       `json:"modified_on,omitempty"`
+	ExitedOn   *time.Time       `json:"exited_on,omitempty"`
+	ExitType   string           `json:"exit_type,omitempty"`
+}
+
+// Step represents a node visited by a run
+type Step struct {
+	Node string     `json:"node,omitempty"`
+	Time *time.Time `json:"time,omitempty"`
+}
+
+// Value represents a result collected by a run, keyed by result key in Run.Values
+type Value struct {
+	Value    string     `json:"value,omitempty"`
+	Category string     `json:"category,omitempty"`
+	Node     string     `json:"node,omitempty"`
+	Time     *time.Time `json:"time,omitempty"`
+	Input    string     `json:"input,omitempty"`
+	Name     string     `json:"name,omitempty"`
+}
+
+// Response represents the response of a request in runs endpoint
+type Response struct {
+	Next     interface{} `json:"next"`
+	Previous interface{} `json:"previous"`
+	Results  []Run       `json:"results"`
+}
+
+// NextCursor returns the cursor to pass in QueryParams to get the next page, or an empty string on the last page
+func (r *Response) NextCursor() string {
+	return rapidpro.Cursor(r.Next)
+}
+
+// QueryParams is a struct that represents the query parameters that can be passed in a request to runs endpoint
+type QueryParams struct {
+	ID        int        `json:"id,omitempty"`
+	UUID      string     `json:"uuid,omitempty"`
+	Flow      string     `json:"flow,omitempty"`
+	Contact   string     `json:"contact,omitempty"`
+	Responded *bool      `json:"responded,omitempty"`
+	Before    *time.Time `json:"before,omitempty"`
+	After     *time.Time `json:"after,omitempty"`
+	Cursor    string     `json:"cursor,omitempty"`
+}
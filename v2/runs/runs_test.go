@@ -0,0 +1,153 @@
+package runs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type RunsTestCase struct {
+	Label        string
+	Method       string
+	QueryParams  *QueryParams
+	Status       int
+	ResponseBody string
+	ResultsCount int
+	Error        error
+}
+
+var responded = true
+
+var testCases = []RunsTestCase{
+	{
+		Label:        "Test Get runs",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       200,
+		ResponseBody: testData[0],
+		ResultsCount: 1,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Get runs with status 500 error",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       500,
+		ResponseBody: "{}",
+		ResultsCount: 0,
+		Error: &client.RapidproRestError{
+			Status:  500,
+			Details: map[string]interface{}{},
+		},
+	},
+	{
+		Label:        "Test Get runs with response error",
+		Method:       "GET",
+		Status:       200,
+		ResponseBody: "{",
+		Error:        errors.New("unexpected EOF"),
+	},
+	{
+		Label:  "Test Get runs with params",
+		Method: "GET",
+		QueryParams: &QueryParams{
+			ID:        12345678,
+			UUID:      "d0be5a4c-a0a4-4b8e-9bcb-ce4d2e0c8bfa",
+			Flow:      "f5901b62-ba76-4003-9c62-72fdacc1b7b7",
+			Contact:   "d33e9ad5-5c35-414c-abd4-e7451c69ff1d",
+			Responded: &responded,
+			After:     timeToPointer(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Before:    timeToPointer(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)),
+			Cursor:    "cD0yMDE1LTExLTExKzExJTNBM40NjQlMkIwMCUzRv",
+		},
+		Status:       200,
+		ResponseBody: testData[1],
+		ResultsCount: 0,
+		Error:        nil,
+	},
+}
+
+func timeToPointer(t time.Time) *time.Time { return &t }
+
+func TestRuns(t *testing.T) {
+	for _, tc := range testCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if tc.QueryParams != nil {
+					assert.Equal(t, "true", r.URL.Query().Get("responded"))
+					assert.Equal(t, tc.QueryParams.Cursor, r.URL.Query().Get("cursor"))
+				}
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			resp, err := service.Get(tc.QueryParams)
+			if err != nil {
+				assert.Equal(t, tc.Error.Error(), err.Error())
+			}
+			if tc.ResultsCount > 0 {
+				assert.Equal(t, tc.ResultsCount, len(resp.Results))
+				run := resp.Results[0]
+				assert.Equal(t, 2, len(run.Path))
+				assert.Equal(t, "blue", run.Values["color"].Value)
+				assert.Equal(t, "Blue", run.Values["color"].Category)
+				assert.Equal(t, "it is blue", run.Values["color"].Input)
+				assert.Equal(t, ExitTypeCompleted, run.ExitType)
+				assert.Equal(t, "", resp.NextCursor())
+			}
+		})
+	}
+}
+
+var testData = []string{
+	`{
+		"next": null,
+		"previous": null,
+		"results": [
+			{
+				"id": 12345678,
+				"uuid": "d0be5a4c-a0a4-4b8e-9bcb-ce4d2e0c8bfa",
+				"flow": {"uuid": "f5901b62-ba76-4003-9c62-72fdacc1b7b7", "name": "Favorite Color"},
+				"contact": {"uuid": "d33e9ad5-5c35-414c-abd4-e7451c69ff1d", "name": "Bob McFlow", "urn": "tel:+593979000111"},
+				"start": null,
+				"responded": true,
+				"path": [
+					{"node": "27a86a1b-6cc4-4ae3-b73d-89650966a82f", "time": "2015-11-11T13:05:50.457742Z"},
+					{"node": "fc32aeb0-ac3e-42a8-9ea7-10248fdf52a1", "time": "2015-11-11T13:03:51.635662Z"}
+				],
+				"values": {
+					"color": {
+						"value": "blue",
+						"category": "Blue",
+						"node": "fc32aeb0-ac3e-42a8-9ea7-10248fdf52a1",
+						"time": "2015-11-11T13:03:51.635662Z",
+						"input": "it is blue",
+						"name": "Color"
+					}
+				},
+				"created_on": "2015-11-11T13:05:57.457742Z",
+				"modified_on": "2015-11-11T13:05:57.576056Z",
+				"exited_on": "2015-11-11T13:05:57.576056Z",
+				"exit_type": "completed"
+			}
+		]
+	}`,
+	`{
+		"next": null,
+		"previous": null,
+		"results": []
+	}`,
+}
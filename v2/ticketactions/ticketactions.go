@@ -0,0 +1,82 @@
+package ticketactions
+
+import (
+	"net/url"
+
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+const PATH = "/v2/ticket_actions.json"
+
+// Actions that can be applied to tickets
+const (
+	ActionAssign      = "assign"
+	ActionAddNote     = "add_note"
+	ActionChangeTopic = "change_topic"
+	ActionClose       = "close"
+	ActionReopen      = "reopen"
+)
+
+type ApiService struct {
+	serviceURL     string
+	requestHandler *rapidpro.RequestHandler
+}
+
+func NewService(requestHandler *rapidpro.RequestHandler, apiURL string) *ApiService {
+	return &ApiService{
+		requestHandler: requestHandler,
+		serviceURL:     apiURL + PATH,
+	}
+}
+
+// Post makes a POST request to ticket actions endpoint to apply an action to up to 100 tickets.
+func (s *ApiService) Post(body PostBody) error {
+	resp, err := s.requestHandler.Post(s.serviceURL, url.Values{}, body, nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Assign assigns the tickets to the user with the given email, an empty email unassigns them.
+func (s *ApiService) Assign(tickets []string, assignee string) error {
+	if assignee == "" {
+		return s.Unassign(tickets)
+	}
+	return s.Post(PostBody{Tickets: tickets, Action: ActionAssign, Assignee: &assignee})
+}
+
+// Unassign removes the assignee of the tickets.
+func (s *ApiService) Unassign(tickets []string) error {
+	return s.Post(PostBody{Tickets: tickets, Action: ActionAssign})
+}
+
+// AddNote adds a note to the tickets.
+func (s *ApiService) AddNote(tickets []string, note string) error {
+	return s.Post(PostBody{Tickets: tickets, Action: ActionAddNote, Note: note})
+}
+
+// ChangeTopic changes the topic of the tickets to the topic with the given uuid.
+func (s *ApiService) ChangeTopic(tickets []string, topic string) error {
+	return s.Post(PostBody{Tickets: tickets, Action: ActionChangeTopic, Topic: topic})
+}
+
+// Close closes the tickets.
+func (s *ApiService) Close(tickets []string) error {
+	return s.Post(PostBody{Tickets: tickets, Action: ActionClose})
+}
+
+// Reopen reopens the tickets.
+func (s *ApiService) Reopen(tickets []string) error {
+	return s.Post(PostBody{Tickets: tickets, Action: ActionReopen})
+}
+
+// PostBody represents the body of a request to ticket actions endpoint. Assignee is always sent since
+// a null assignee is how the assign action unassigns tickets, other actions ignore it.
+type PostBody struct {
+	Tickets  []string `json:"tickets"`
+	Action   string   `json:"action"`
+	Assignee *string  `json:"assignee"`
+	Note     string   `json:"note,omitempty"`
+	Topic    string   `json:"topic,omitempty"`
+}
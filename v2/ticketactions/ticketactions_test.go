@@ -0,0 +1,117 @@
+package ticketactions
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+var tickets = []string{"55b6606d-9e89-45d1-a3e2-dc11f19f78df", "bef96b71-865d-480a-a1a1-5b79b3c2f1a4"}
+
+type TicketActionsTestCase struct {
+	Label        string
+	Call         func(s *ApiService) error
+	Status       int
+	ResponseBody string
+	Expected     PostBody
+	Error        error
+}
+
+var testCases = []TicketActionsTestCase{
+	{
+		Label:    "Test assign tickets",
+		Call:     func(s *ApiService) error { return s.Assign(tickets, "bob@nyaruka.com") },
+		Status:   204,
+		Expected: PostBody{Tickets: tickets, Action: ActionAssign, Assignee: stringToPointer("bob@nyaruka.com")},
+	},
+	{
+		Label:    "Test add note to tickets",
+		Call:     func(s *ApiService) error { return s.AddNote(tickets, "Looks important") },
+		Status:   204,
+		Expected: PostBody{Tickets: tickets, Action: ActionAddNote, Note: "Looks important"},
+	},
+	{
+		Label:    "Test change topic of tickets",
+		Call:     func(s *ApiService) error { return s.ChangeTopic(tickets, "040edbfe-be55-48f3-864d-a4a7147c447b") },
+		Status:   204,
+		Expected: PostBody{Tickets: tickets, Action: ActionChangeTopic, Topic: "040edbfe-be55-48f3-864d-a4a7147c447b"},
+	},
+	{
+		Label:    "Test close tickets",
+		Call:     func(s *ApiService) error { return s.Close(tickets) },
+		Status:   204,
+		Expected: PostBody{Tickets: tickets, Action: ActionClose},
+	},
+	{
+		Label:    "Test reopen tickets",
+		Call:     func(s *ApiService) error { return s.Reopen(tickets) },
+		Status:   204,
+		Expected: PostBody{Tickets: tickets, Action: ActionReopen},
+	},
+	{
+		Label:        "Test ticket action with error",
+		Call:         func(s *ApiService) error { return s.Assign(tickets, "nobody@nyaruka.com") },
+		Status:       400,
+		ResponseBody: `{"assignee": ["No such object: nobody@nyaruka.com"]}`,
+		Expected:     PostBody{Tickets: tickets, Action: ActionAssign, Assignee: stringToPointer("nobody@nyaruka.com")},
+		Error: &client.RapidproRestError{
+			Status:  400,
+			Details: map[string]interface{}{"assignee": []interface{}{"No such object: nobody@nyaruka.com"}},
+		},
+	},
+}
+
+func stringToPointer(s string) *string { return &s }
+
+func TestTicketActions(t *testing.T) {
+	for _, tc := range testCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				body := PostBody{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, tc.Expected, body)
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			assert.Equal(t, tc.Error, tc.Call(service))
+		})
+	}
+}
+
+func TestTicketActionsUnassign(t *testing.T) {
+	for _, call := range []func(s *ApiService) error{
+		func(s *ApiService) error { return s.Unassign(tickets) },
+		func(s *ApiService) error { return s.Assign(tickets, "") },
+	} {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				body := map[string]interface{}{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "assign", body["action"])
+				assignee, ok := body["assignee"]
+				assert.True(t, ok)
+				assert.Nil(t, assignee)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+
+		defaultClient := &client.Client{
+			Credentials: &client.Credentials{Token: "token123"},
+		}
+		service := NewService(client.NewRequestHandler(defaultClient), mockServer.URL)
+		assert.NoError(t, call(service))
+		mockServer.Close()
+	}
+}
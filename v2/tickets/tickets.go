@@ -0,0 +1,112 @@
+package tickets
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+const PATH = "/v2/tickets.json"
+
+// Ticket statuses
+const (
+	StatusOpen   = "open"
+	StatusClosed = "closed"
+)
+
+type ApiService struct {
+	serviceURL     string
+	requestHandler *rapidpro.RequestHandler
+}
+
+func NewService(requestHandler *rapidpro.RequestHandler, apiURL string) *ApiService {
+	return &ApiService{
+		requestHandler: requestHandler,
+		serviceURL:     apiURL + PATH,
+	}
+}
+
+// Get makes a GET request to tickets endpoint with *QueryParams and returns a Response.
+func (s *ApiService) Get(params *QueryParams) (*Response, error) {
+	data := url.Values{}
+	headers := make(map[string]interface{})
+
+	if params != nil {
+		if params.UUID != "" {
+			data.Set("uuid", params.UUID)
+		}
+		if params.Contact != "" {
+			data.Set("contact", params.Contact)
+		}
+		if params.Status != "" {
+			data.Set("status", params.Status)
+		}
+		if params.Ticketer != "" {
+			data.Set("ticketer", params.Ticketer)
+		}
+		if params.Before != nil {
+			data.Set("before", params.Before.Format(time.RFC3339))
+		}
+		if params.After != nil {
+			data.Set("after", params.After.Format(time.RFC3339))
+		}
+	}
+
+	resp, err := s.requestHandler.Get(s.serviceURL, data, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Response{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Ticket represents a ticket object
+type Ticket struct {
+	UUID     string `json:"uuid,omitempty"`
+	Ticketer struct {
+		UUID string `json:"uuid,omitempty"`
+		Name string `json:"name,omitempty"`
+	} `json:"ticketer,omitempty"`
+	Contact struct {
+		UUID string `json:"uuid,omitempty"`
+		Name string `json:"name,omitempty"`
+	} `json:"contact,omitempty"`
+	Status string `json:"status,omitempty"`
+	Topic  *struct {
+		UUID string `json:"uuid,omitempty"`
+		Name string `json:"name,omitempty"`
+	} `json:"topic,omitempty"`
+	Body     string `json:"body,omitempty"`
+	Assignee *struct {
+		Email string `json:"email,omitempty"`
+		Name  string `json:"name,omitempty"`
+	} `json:"assignee,omitempty"`
+	OpenedOn   *time.Time `json:"opened_on,omitempty"`
+	ModifiedOn *time.Time `json:"modified_on,omitempty"`
+	ClosedOn   *time.Time `json:"closed_on,omitempty"`
+}
+
+// Response represents the response of a request in tickets endpoint
+type Response struct {
+	Next     interface{} `json:"next"`
+	Previous interface{} `json:"previous"`
+	Results  []Ticket    `json:"results"`
+}
+
+// QueryParams is a struct that represents the query parameters that can be passed in a request to tickets endpoint
+type QueryParams struct {
+	UUID     string     `json:"uuid,omitempty"`
+	Contact  string     `json:"contact,omitempty"`
+	Status   string     `json:"status,omitempty"`
+	Ticketer string     `json:"ticketer,omitempty"`
+	Before   *time.Time `json:"before,omitempty"`
+	After    *time.Time `json:"after,omitempty"`
+}
@@ -0,0 +1,117 @@
+package tickets
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type TicketsTestCase struct {
+	Label        string
+	Method       string
+	QueryParams  *QueryParams
+	Status       int
+	ResponseBody string
+	ResultsCount int
+	Error        error
+}
+
+var testCases = []TicketsTestCase{
+	{
+		Label:        "Test Get tickets",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       200,
+		ResponseBody: testData[0],
+		ResultsCount: 1,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Get tickets with response error",
+		Method:       "GET",
+		Status:       200,
+		ResponseBody: "{",
+		Error:        errors.New("unexpected EOF"),
+	},
+	{
+		Label:  "Test Get tickets with params",
+		Method: "GET",
+		QueryParams: &QueryParams{
+			Contact:  "f5901b62-ba76-4003-9c62-72fdacc1b7b7",
+			Status:   StatusOpen,
+			Ticketer: "9a8b001e-a913-486c-80f4-1356e23f582e",
+			After:    timeToPointer(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Before:   timeToPointer(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		Status:       200,
+		ResponseBody: testData[1],
+		ResultsCount: 0,
+		Error:        nil,
+	},
+}
+
+func timeToPointer(t time.Time) *time.Time { return &t }
+
+func TestTickets(t *testing.T) {
+	for _, tc := range testCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if tc.QueryParams != nil {
+					assert.Equal(t, tc.QueryParams.Status, r.URL.Query().Get("status"))
+					assert.Equal(t, tc.QueryParams.Ticketer, r.URL.Query().Get("ticketer"))
+				}
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			resp, err := service.Get(tc.QueryParams)
+			if err != nil {
+				assert.Equal(t, tc.Error.Error(), err.Error())
+			}
+			if tc.ResultsCount > 0 {
+				assert.Equal(t, tc.ResultsCount, len(resp.Results))
+				assert.Equal(t, "Weather", resp.Results[0].Topic.Name)
+				assert.Equal(t, "bob@nyaruka.com", resp.Results[0].Assignee.Email)
+			}
+		})
+	}
+}
+
+var testData = []string{
+	`{
+		"next": null,
+		"previous": null,
+		"results": [
+			{
+				"uuid": "9a8b001e-a913-486c-80f4-1356e23f582e",
+				"ticketer": {"uuid": "9a8b001e-a913-486c-80f4-1356e23f582e", "name": "Email (bob@acme.com)"},
+				"contact": {"uuid": "f5901b62-ba76-4003-9c62-72fdacc1b7b7", "name": "Jim"},
+				"status": "open",
+				"topic": {"uuid": "040edbfe-be55-48f3-864d-a4a7147c447b", "name": "Weather"},
+				"body": "Where are my cookies?",
+				"assignee": {"email": "bob@nyaruka.com", "name": "Bob McFlow"},
+				"opened_on": "2015-11-11T13:05:57.457742Z",
+				"modified_on": "2015-11-11T13:05:57.457742Z",
+				"closed_on": null
+			}
+		]
+	}`,
+	`{
+		"next": null,
+		"previous": null,
+		"results": []
+	}`,
+}
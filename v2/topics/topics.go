@@ -0,0 +1,106 @@
+package topics
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+const PATH = "/v2/topics.json"
+
+type ApiService struct {
+	serviceURL     string
+	requestHandler *rapidpro.RequestHandler
+}
+
+func NewService(requestHandler *rapidpro.RequestHandler, apiURL string) *ApiService {
+	return &ApiService{
+		requestHandler: requestHandler,
+		serviceURL:     apiURL + PATH,
+	}
+}
+
+// Get makes a GET request to topics endpoint with *QueryParams and returns a Response.
+func (s *ApiService) Get(params *QueryParams) (*Response, error) {
+	data := url.Values{}
+	headers := make(map[string]interface{})
+
+	if params != nil {
+		if params.UUID != "" {
+			data.Set("uuid", params.UUID)
+		}
+		if params.Before != nil {
+			data.Set("before", params.Before.Format(time.RFC3339))
+		}
+		if params.After != nil {
+			data.Set("after", params.After.Format(time.RFC3339))
+		}
+	}
+
+	resp, err := s.requestHandler.Get(s.serviceURL, data, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Response{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Post makes a POST request to topics endpoint to create a new topic and returns it.
+func (s *ApiService) Post(body PostBody) (*Topic, error) {
+	return s.post(url.Values{}, body)
+}
+
+// Update makes a POST request to topics endpoint to rename the topic with the given uuid and returns it.
+func (s *ApiService) Update(uuid string, body PostBody) (*Topic, error) {
+	queryParams := url.Values{}
+	queryParams.Set("uuid", uuid)
+	return s.post(queryParams, body)
+}
+
+func (s *ApiService) post(queryParams url.Values, body PostBody) (*Topic, error) {
+	resp, err := s.requestHandler.Post(s.serviceURL, queryParams, body, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Topic{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Topic represents a topic object
+type Topic struct {
+	UUID      string     `json:"uuid,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	CreatedOn *time.Time `json:"created_on,omitempty"`
+}
+
+// Response represents the response of a request in topics endpoint
+type Response struct {
+	Next     interface{} `json:"next"`
+	Previous interface{} `json:"previous"`
+	Results  []Topic     `json:"results"`
+}
+
+// QueryParams is a struct that represents the query parameters that can be passed in a request to topics endpoint
+type QueryParams struct {
+	UUID   string     `json:"uuid,omitempty"`
+	Before *time.Time `json:"before,omitempty"`
+	After  *time.Time `json:"after,omitempty"`
+}
+
+// PostBody represents the body of a request to create or update a topic
+type PostBody struct {
+	Name string `json:"name"`
+}
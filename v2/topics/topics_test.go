@@ -0,0 +1,161 @@
+package topics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type TopicsTestCase struct {
+	Label        string
+	Method       string
+	UUID         string
+	QueryParams  *QueryParams
+	PostBody     *PostBody
+	Status       int
+	ResponseBody string
+	ResultsCount int
+	Error        error
+}
+
+var testCases = []TopicsTestCase{
+	{
+		Label:        "Test Get topics",
+		Method:       "GET",
+		QueryParams:  nil,
+		Status:       200,
+		ResponseBody: testDataGet[0],
+		ResultsCount: 1,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Get topics with response error",
+		Method:       "GET",
+		Status:       200,
+		ResponseBody: "{",
+		Error:        errors.New("unexpected EOF"),
+	},
+	{
+		Label:  "Test Get topics with params",
+		Method: "GET",
+		QueryParams: &QueryParams{
+			UUID:   "040edbfe-be55-48f3-864d-a4a7147c447b",
+			After:  timeToPointer(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Before: timeToPointer(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		Status:       200,
+		ResponseBody: testDataGet[1],
+		ResultsCount: 0,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Post topics",
+		Method:       "POST",
+		PostBody:     &PostBody{Name: "Weather"},
+		Status:       201,
+		ResponseBody: testDataPost,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Update topics",
+		Method:       "POST",
+		UUID:         "040edbfe-be55-48f3-864d-a4a7147c447b",
+		PostBody:     &PostBody{Name: "Weather"},
+		Status:       200,
+		ResponseBody: testDataPost,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Post topics with error",
+		Method:       "POST",
+		PostBody:     &PostBody{Name: "Weather"},
+		Status:       400,
+		ResponseBody: `{"name": ["This field must be unique."]}`,
+		Error: &client.RapidproRestError{
+			Status:  400,
+			Details: map[string]interface{}{"name": []interface{}{"This field must be unique."}},
+		},
+	},
+}
+
+func timeToPointer(t time.Time) *time.Time { return &t }
+
+func TestTopics(t *testing.T) {
+	for _, tc := range testCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if tc.Method == "POST" {
+					assert.Equal(t, tc.UUID, r.URL.Query().Get("uuid"))
+					body := PostBody{}
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, *tc.PostBody, body)
+				}
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			if tc.Method == "GET" {
+				resp, err := service.Get(tc.QueryParams)
+				if err != nil {
+					assert.Equal(t, tc.Error.Error(), err.Error())
+				}
+				if tc.ResultsCount > 0 {
+					assert.Equal(t, tc.ResultsCount, len(resp.Results))
+				}
+				return
+			}
+
+			var topic *Topic
+			var err error
+			if tc.UUID != "" {
+				topic, err = service.Update(tc.UUID, *tc.PostBody)
+			} else {
+				topic, err = service.Post(*tc.PostBody)
+			}
+			assert.Equal(t, tc.Error, err)
+			if err == nil {
+				assert.Equal(t, "040edbfe-be55-48f3-864d-a4a7147c447b", topic.UUID)
+				assert.Equal(t, "Weather", topic.Name)
+			}
+		})
+	}
+}
+
+var testDataGet = []string{
+	`{
+		"next": null,
+		"previous": null,
+		"results": [
+			{
+				"uuid": "040edbfe-be55-48f3-864d-a4a7147c447b",
+				"name": "Weather",
+				"created_on": "2013-02-27T09:06:15.456Z"
+			}
+		]
+	}`,
+	`{
+		"next": null,
+		"previous": null,
+		"results": []
+	}`,
+}
+
+var testDataPost = `{
+	"uuid": "040edbfe-be55-48f3-864d-a4a7147c447b",
+	"name": "Weather",
+	"created_on": "2013-02-27T09:06:15.456Z"
+}`
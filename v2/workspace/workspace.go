@@ -0,0 +1,69 @@
+package workspace
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	rapidpro "github.com/rasoro/rapidpro-api-go/client"
+)
+
+const PATH = "/v2/workspace.json"
+
+// Date styles
+const (
+	DateStyleDayFirst   = "day_first"
+	DateStyleMonthFirst = "month_first"
+	DateStyleYearFirst  = "year_first"
+)
+
+type ApiService struct {
+	serviceURL     string
+	requestHandler *rapidpro.RequestHandler
+}
+
+func NewService(requestHandler *rapidpro.RequestHandler, apiURL string) *ApiService {
+	return &ApiService{
+		requestHandler: requestHandler,
+		serviceURL:     apiURL + PATH,
+	}
+}
+
+// Get makes a GET request to workspace endpoint and returns the Workspace of the token in use.
+func (s *ApiService) Get() (*Workspace, error) {
+	headers := make(map[string]interface{})
+
+	resp, err := s.requestHandler.Get(s.serviceURL, url.Values{}, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Workspace{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Workspace represents a workspace object
+type Workspace struct {
+	UUID            string   `json:"uuid,omitempty"`
+	Name            string   `json:"name,omitempty"`
+	Country         string   `json:"country,omitempty"`
+	Languages       []string `json:"languages,omitempty"`
+	PrimaryLanguage string   `json:"primary_language,omitempty"`
+	Timezone        string   `json:"timezone,omitempty"`
+	DateStyle       string   `json:"date_style,omitempty"`
+	Credits         *struct {
+		Used      int `json:"used"`
+		Remaining int `json:"remaining"`
+	} `json:"credits,omitempty"`
+	Anon bool `json:"anon"`
+}
+
+// Location returns the time.Location of the workspace timezone
+func (w *Workspace) Location() (*time.Location, error) {
+	return time.LoadLocation(w.Timezone)
+}
@@ -0,0 +1,93 @@
+package workspace
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/rasoro/rapidpro-api-go/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type WorkspaceTestCase struct {
+	Label        string
+	Method       string
+	Status       int
+	ResponseBody string
+	Error        error
+}
+
+var testCases = []WorkspaceTestCase{
+	{
+		Label:        "Test Get workspace",
+		Method:       "GET",
+		Status:       200,
+		ResponseBody: testData,
+		Error:        nil,
+	},
+	{
+		Label:        "Test Get workspace with status 403 error",
+		Method:       "GET",
+		Status:       403,
+		ResponseBody: `{"detail": "Invalid token"}`,
+		Error: &client.RapidproRestError{
+			Status:  403,
+			Details: map[string]interface{}{"detail": "Invalid token"},
+		},
+	},
+	{
+		Label:        "Test Get workspace with response error",
+		Method:       "GET",
+		Status:       200,
+		ResponseBody: "{",
+		Error:        errors.New("unexpected EOF"),
+	},
+}
+
+func TestWorkspace(t *testing.T) {
+	for _, tc := range testCases {
+		mockServer := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.ResponseBody))
+			}))
+		defer mockServer.Close()
+
+		t.Run(tc.Label, func(t *testing.T) {
+			defaultClient := &client.Client{
+				Credentials: &client.Credentials{Token: "token123"},
+			}
+			requestHandler := client.NewRequestHandler(defaultClient)
+			service := NewService(requestHandler, mockServer.URL)
+			workspace, err := service.Get()
+			if err != nil {
+				assert.Equal(t, tc.Error.Error(), err.Error())
+				return
+			}
+			assert.Equal(t, "Nyaruka", workspace.Name)
+			assert.Equal(t, []string{"eng", "fra"}, workspace.Languages)
+			assert.Equal(t, "eng", workspace.PrimaryLanguage)
+			assert.Equal(t, DateStyleDayFirst, workspace.DateStyle)
+			assert.Equal(t, 1234, workspace.Credits.Used)
+			assert.False(t, workspace.Anon)
+
+			location, err := workspace.Location()
+			assert.NoError(t, err)
+			assert.Equal(t, "Africa/Kigali", location.String())
+		})
+	}
+}
+
+var testData = `{
+	"uuid": "6a44ca78-a4c2-4862-a7d3-2932f9b3a7c3",
+	"name": "Nyaruka",
+	"country": "RW",
+	"languages": ["eng", "fra"],
+	"primary_language": "eng",
+	"timezone": "Africa/Kigali",
+	"date_style": "day_first",
+	"credits": {"used": 1234, "remaining": 2345},
+	"anon": false
+}`